@@ -35,3 +35,53 @@ data "minio_canned_policy" "console_admin" {
 data "minio_group" "mygroup" {
   name = "group1"
 }
+
+data "minio_users" "all_users" {}
+
+data "minio_groups" "team_groups" {
+  prefix = "team-"
+}
+
+data "minio_canned_policies" "readonly_policies" {
+  name_regex = "^read"
+}
+
+data "minio_buckets" "all_buckets" {}
+
+data "minio_server_info" "server" {}
+
+output "offline_drives" {
+  value = data.minio_server_info.server.offline_drives
+}
+
+data "minio_bucket_usage" "data_bucket1" {
+  bucket = "bucket"
+}
+
+output "data_bucket1_tags" {
+  value = data.minio_bucket.data_bucket1.tags
+}
+
+data "minio_presigned_url" "download" {
+  bucket     = "bucket"
+  key        = "reports/latest.csv"
+  expires_in = 900
+  response_headers = {
+    "Content-Disposition" = "attachment; filename=\"latest.csv\""
+  }
+}
+
+data "minio_presigned_url" "upload_form" {
+  bucket             = "bucket"
+  method             = "POST"
+  key_prefix         = "uploads/"
+  content_length_max = 10485760
+}
+
+data "minio_kms_status" "kms" {}
+
+data "minio_site_replication_status" "replication" {}
+
+output "replication_in_sync" {
+  value = data.minio_site_replication_status.replication.in_sync
+}
@@ -7,7 +7,7 @@ require (
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-docs v0.5.0 // indirect
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
-	github.com/minio/madmin-go v1.1.0
-	github.com/minio/minio-go/v7 v7.0.13
+	github.com/minio/madmin-go v1.7.5
+	github.com/minio/minio-go/v7 v7.0.41
 	github.com/minio/pkg v1.1.0 // indirect
 )
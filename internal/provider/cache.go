@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/minio/madmin-go"
+)
+
+// Caches the results of bulk listing calls, so they are only done once per
+// provider run instead of once per resource.
+// Every write to users or groups increments the generation, which
+// invalidates all cached results.
+type listCache struct {
+	mutex      sync.Mutex
+	generation uint64
+
+	users           map[string]madmin.UserInfo
+	usersLoaded     bool
+	usersGeneration uint64
+
+	groups           []string
+	groupsLoaded     bool
+	groupsGeneration uint64
+}
+
+// Invalidate all cached listings.
+// Must be called after every change to users or groups.
+func (c *minioContext) invalidateListCache() {
+	c.cache.mutex.Lock()
+	defer c.cache.mutex.Unlock()
+	c.cache.generation++
+}
+
+// List all users, using the cache if possible.
+func (c *minioContext) listUsers(ctx context.Context) (map[string]madmin.UserInfo, error) {
+	c.cache.mutex.Lock()
+	generation := c.cache.generation
+	if c.cache.usersLoaded && c.cache.usersGeneration == generation {
+		users := c.cache.users
+		c.cache.mutex.Unlock()
+		return users, nil
+	}
+	c.cache.mutex.Unlock()
+
+	users, err := c.admin.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The result is stored with the generation from before the request, so
+	// writes that happened in the meantime invalidate it.
+	c.cache.mutex.Lock()
+	c.cache.users = users
+	c.cache.usersLoaded = true
+	c.cache.usersGeneration = generation
+	c.cache.mutex.Unlock()
+	return users, nil
+}
+
+// List all groups, using the cache if possible.
+func (c *minioContext) listGroups(ctx context.Context) ([]string, error) {
+	c.cache.mutex.Lock()
+	generation := c.cache.generation
+	if c.cache.groupsLoaded && c.cache.groupsGeneration == generation {
+		groups := c.cache.groups
+		c.cache.mutex.Unlock()
+		return groups, nil
+	}
+	c.cache.mutex.Unlock()
+
+	groups, err := c.admin.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.mutex.Lock()
+	c.cache.groups = groups
+	c.cache.groupsLoaded = true
+	c.cache.groupsGeneration = generation
+	c.cache.mutex.Unlock()
+	return groups, nil
+}
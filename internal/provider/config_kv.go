@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Shared functionality for resources built on the server configuration
+// key/value API.
+
+// Build the name of a configuration subsystem target, e.g. `notify_webhook:primary`.
+// An empty target refers to the default target.
+func configKVName(subsystem string, target string) string {
+	if target == "" || target == madmin.Default {
+		return subsystem
+	}
+	return subsystem + madmin.SubSystemSeparator + target
+}
+
+// Quote a configuration value for the key/value API.
+func configKVQuote(value string) string {
+	if strings.Contains(value, madmin.KvDoubleQuote) {
+		return madmin.KvSingleQuote + value + madmin.KvSingleQuote
+	}
+	return madmin.KvDoubleQuote + value + madmin.KvDoubleQuote
+}
+
+// Load the configuration of a subsystem target.
+// Returns nil if the target is not configured.
+func configKVGet(ctx context.Context, client *madmin.AdminClient, subsystem string, target string) (map[string]string, error) {
+	// The whole subsystem is loaded, since requesting a missing target is an
+	// error that can not be told apart from other errors.
+	raw, err := client.GetConfigKV(ctx, subsystem)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load configuration %s: %s", subsystem, err)
+	}
+
+	configs, err := madmin.ParseServerConfigOutput(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse configuration %s: %s", subsystem, err)
+	}
+	name := configKVName(subsystem, target)
+	for _, config := range configs {
+		if config.SubSystem != subsystem || configKVName(subsystem, config.Target) != name {
+			continue
+		}
+		values := make(map[string]string)
+		for _, kv := range config.KV {
+			values[kv.Key] = kv.Value
+		}
+		return values, nil
+	}
+	return nil, nil
+}
+
+// Set configuration values of a subsystem target.
+// Keys that are not given keep their current value.
+// Returns true if the server must be restarted to apply the change.
+func configKVSet(ctx context.Context, client *madmin.AdminClient, subsystem string, target string, values map[string]string) (bool, error) {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{configKVName(subsystem, target)}
+	for _, key := range keys {
+		pairs = append(pairs, key+madmin.KvSeparator+configKVQuote(values[key]))
+	}
+
+	restart, err := client.SetConfigKV(ctx, strings.Join(pairs, madmin.KvSpaceSeparator))
+	if err != nil {
+		return false, fmt.Errorf("Could not set configuration %s: %s", configKVName(subsystem, target), err)
+	}
+	return restart, nil
+}
+
+// Reset configuration values of a subsystem target to their defaults.
+// Resets the whole target if no keys are given.
+// Returns true if the server must be restarted to apply the change.
+func configKVDelete(ctx context.Context, client *madmin.AdminClient, subsystem string, target string, keys ...string) (bool, error) {
+	name := configKVName(subsystem, target)
+	restart, err := client.DelConfigKV(ctx, strings.Join(append([]string{name}, keys...), madmin.KvSpaceSeparator))
+	if err != nil {
+		return false, fmt.Errorf("Could not reset configuration %s: %s", name, err)
+	}
+	return restart, nil
+}
+
+// Build a warning about a required restart.
+func configKVRestartWarning(restart bool, subsystem string, target string) diag.Diagnostics {
+	if !restart {
+		return nil
+	}
+	return []diag.Diagnostic{diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Server restart required",
+		Detail:   fmt.Sprintf("The change to %s is only applied after the server is restarted.", configKVName(subsystem, target)),
+	}}
+}
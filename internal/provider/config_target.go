@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Shared functionality for typed resources that manage a target of a server
+// configuration subsystem, like a notification target.
+// The attribute names of these resources are the configuration keys.
+
+const (
+	keyConfigTargetName            = "name"
+	keyConfigTargetEnable          = "enable"
+	keyConfigTargetARN             = "arn"
+	keyConfigTargetRestartRequired = "restart_required"
+	keyConfigTargetQueueDir        = "queue_dir"
+	keyConfigTargetQueueLimit      = "queue_limit"
+)
+
+// Describes a typed configuration target resource.
+type configTarget struct {
+	// The configuration subsystem, e.g. `notify_webhook`.
+	subsystem string
+	// The type in the ARN of notification targets, e.g. `webhook`.
+	// Empty for targets without an ARN.
+	arnType string
+	// The schema of the configuration keys.
+	fields objectSchema
+	// True for subsystems without named targets, which only have the default
+	// target. The resource has no name then and the subsystem is the ID.
+	singleton bool
+}
+
+// Determine the name of the managed target.
+func (t configTarget) targetName(d *schema.ResourceData) string {
+	if t.singleton {
+		return ""
+	}
+	if d.Id() != "" {
+		return d.Id()
+	}
+	return d.Get(keyConfigTargetName).(string)
+}
+
+func (t configTarget) schema() objectSchema {
+	s := objectSchema{
+		keyConfigTargetEnable: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Enables the target.",
+		},
+		keyConfigTargetRestartRequired: &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if the server must be restarted to apply the last change.",
+		},
+	}
+	if !t.singleton {
+		s[keyConfigTargetName] = &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the target. This is also the unique ID.",
+			ForceNew:    true,
+		}
+	}
+	if t.arnType != "" {
+		s[keyConfigTargetARN] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ARN of the target, for use in bucket notification configurations.",
+		}
+	}
+	for key, field := range t.fields {
+		s[key] = field
+	}
+	return s
+}
+
+func (t configTarget) resource(description string) *schema.Resource {
+	return &schema.Resource{
+		Description: description,
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return t.create(ctx, d, m)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return t.read(ctx, d, m)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return t.update(ctx, d, m)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return t.delete(ctx, d, m)
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			for key := range t.fields {
+				if d.HasChange(key) {
+					return d.SetNewComputed(keyConfigTargetRestartRequired)
+				}
+			}
+			if d.HasChange(keyConfigTargetEnable) {
+				return d.SetNewComputed(keyConfigTargetRestartRequired)
+			}
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: t.schema(),
+	}
+}
+
+// Add the persistent event queue keys, which are shared by all notification
+// targets, to a schema.
+func withNotifyQueue(fields objectSchema) objectSchema {
+	fields[keyConfigTargetQueueDir] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The directory on the server to store undelivered events in.\nEvents are only persisted if this is set.",
+	}
+	fields[keyConfigTargetQueueLimit] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Description:  "The maximum number of undelivered events in the queue. The server default is used if not set.",
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	return fields
+}
+
+// Convert an attribute value to a configuration value.
+// Returns an empty string for zero values.
+func configTargetFormat(field *schema.Schema, value interface{}) string {
+	switch field.Type {
+	case schema.TypeBool:
+		if value.(bool) {
+			return madmin.EnableOn
+		}
+		return madmin.EnableOff
+	case schema.TypeInt:
+		if value.(int) == 0 {
+			return ""
+		}
+		return strconv.Itoa(value.(int))
+	case schema.TypeList:
+		return strings.Join(interfaceToStringSlice(value.([]interface{})), ",")
+	default:
+		return value.(string)
+	}
+}
+
+// Convert a configuration value to an attribute value.
+func configTargetParse(field *schema.Schema, value string) interface{} {
+	switch field.Type {
+	case schema.TypeBool:
+		return value == madmin.EnableOn || value == "true"
+	case schema.TypeInt:
+		parsed, _ := strconv.Atoi(value)
+		return parsed
+	case schema.TypeList:
+		return stringSliceFilterEmpty(strings.Split(value, ","))
+	default:
+		return value
+	}
+}
+
+// Build the configuration values.
+// Returns the values to set and the keys to reset to their defaults.
+func (t configTarget) values(d *schema.ResourceData) (map[string]string, []string) {
+	values := map[string]string{
+		keyConfigTargetEnable: configTargetFormat(t.schema()[keyConfigTargetEnable], d.Get(keyConfigTargetEnable)),
+	}
+	var reset []string
+	for key, field := range t.fields {
+		if value := configTargetFormat(field, d.Get(key)); value != "" {
+			values[key] = value
+		} else if d.HasChange(key) && !d.IsNewResource() {
+			reset = append(reset, key)
+		}
+	}
+	return values, reset
+}
+
+func (t configTarget) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	name := t.targetName(d)
+
+	values, _ := t.values(d)
+	restart, err := configKVSet(ctx, client, t.subsystem, name, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyConfigTargetRestartRequired, restart); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if t.singleton {
+		d.SetId(t.subsystem)
+	} else {
+		d.SetId(name)
+	}
+	diags := configKVRestartWarning(restart, t.subsystem, name)
+	return append(diags, t.read(ctx, d, m)...)
+}
+
+func (t configTarget) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	name := t.targetName(d)
+
+	current, err := configKVGet(ctx, client, t.subsystem, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if current == nil {
+		// The target was removed outside of terraform.
+		d.SetId("")
+		return diags
+	}
+
+	values := map[string]interface{}{
+		keyConfigTargetEnable: current[keyConfigTargetEnable] != madmin.EnableOff,
+	}
+	if !t.singleton {
+		values[keyConfigTargetName] = name
+	}
+	for key, field := range t.fields {
+		// Sensitive values can be redacted by the server, so they are kept
+		// as configured.
+		if value, ok := current[key]; ok && !field.Sensitive {
+			values[key] = configTargetParse(field, value)
+		}
+	}
+
+	if t.arnType != "" {
+		info, err := client.ServerInfo(ctx)
+		if err != nil {
+			return diag.Errorf("Could not load server info: %s", err)
+		}
+		values[keyConfigTargetARN] = "arn:minio:sqs:" + info.Region + ":" + name + ":" + t.arnType
+	}
+
+	if err := dataSetValues(d, values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func (t configTarget) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	name := t.targetName(d)
+
+	values, reset := t.values(d)
+	restart, err := configKVSet(ctx, client, t.subsystem, name, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(reset) > 0 {
+		resetRestart, err := configKVDelete(ctx, client, t.subsystem, name, reset...)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		restart = restart || resetRestart
+	}
+	if err := d.Set(keyConfigTargetRestartRequired, restart); err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := configKVRestartWarning(restart, t.subsystem, name)
+	return append(diags, t.read(ctx, d, m)...)
+}
+
+func (t configTarget) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	name := t.targetName(d)
+
+	restart, err := configKVDelete(ctx, client, t.subsystem, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return configKVRestartWarning(restart, t.subsystem, name)
+}
@@ -1,12 +1,222 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const (
+	keyBucketCreationDate           = "creation_date"
+	keyBucketObjectLockEnabled      = "object_lock_enabled"
+	keyBucketObjectLockMode         = "object_lock_mode"
+	keyBucketObjectLockValidity     = "object_lock_validity"
+	keyBucketObjectLockValidityUnit = "object_lock_validity_unit"
+	keyBucketEncryptionAlgorithm    = "encryption_algorithm"
+	keyBucketEncryptionKMSKeyID     = "encryption_kms_key_id"
+	keyBucketTags                   = "tags"
+	keyBucketQuota                  = "quota"
+	keyBucketQuotaType              = "quota_type"
+	keyBucketPolicy                 = "policy"
+	keyBucketLifecycle              = "lifecycle_configuration"
+	keyBucketReplication            = "replication_configuration"
+	keyBucketNotification           = "notification_configuration"
+)
+
+// Error codes returned when an optional bucket configuration is not set.
+var bucketConfigNotFoundCodes = []string{
+	"ObjectLockConfigurationNotFoundError",
+	"ServerSideEncryptionConfigurationNotFoundError",
+	"NoSuchTagSet",
+	"NoSuchLifecycleConfiguration",
+	"XMinioAdminNoSuchQuotaConfiguration",
+}
+
+// Error codes returned when the credentials lack admin permissions.
+var adminAccessDeniedCodes = []string{
+	"AccessDenied",
+	"XMinioAdminNotAllowed",
+}
+
 func datasourceBucket() *schema.Resource {
+	computed := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        t,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	s := schemaBucket()
+	s[keyBucketName].ForceNew = false
+	s[keyBucketName].Description = "The name of the bucket."
+	s[keyBucketVersioningEnabled].Optional = false
+	s[keyBucketVersioningEnabled].Default = nil
+	s[keyBucketVersioningEnabled].Computed = true
+	s[keyBucketVersioningEnabled].Description = "True if versioning is enabled."
+	s[keyBucketCreationDate] = computed(schema.TypeString, "The creation date of the bucket in RFC 3339 format.")
+	s[keyBucketObjectLockEnabled] = computed(schema.TypeBool, "True if object locking is enabled.")
+	s[keyBucketObjectLockMode] = computed(schema.TypeString, "The default retention mode, `GOVERNANCE` or `COMPLIANCE`.\nEmpty if there is no default retention.")
+	s[keyBucketObjectLockValidity] = computed(schema.TypeInt, "The default retention period, in units of `object_lock_validity_unit`.")
+	s[keyBucketObjectLockValidityUnit] = computed(schema.TypeString, "The unit of the default retention period, `DAYS` or `YEARS`.")
+	s[keyBucketEncryptionAlgorithm] = computed(schema.TypeString, "The default server side encryption algorithm, `AES256` or `aws:kms`.\nEmpty if there is no default encryption.")
+	s[keyBucketEncryptionKMSKeyID] = computed(schema.TypeString, "The KMS key used for the default server side encryption.")
+	s[keyBucketTags] = &schema.Schema{
+		Type: schema.TypeMap,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Computed:    true,
+		Description: "The tags of the bucket.",
+	}
+	s[keyBucketQuota] = computed(schema.TypeInt, "The quota of the bucket in bytes.\n0 if there is no quota, or if the credentials lack admin permissions to read it.")
+	s[keyBucketQuotaType] = computed(schema.TypeString, "The type of the quota, e.g. `hard`.\nEmpty if there is no quota, or if the credentials lack admin permissions to read it.")
+	s[keyBucketPolicy] = computed(schema.TypeString, "The bucket policy as JSON.\nEmpty if there is no policy.")
+	s[keyBucketLifecycle] = computed(schema.TypeString, "The lifecycle configuration as JSON.\nEmpty if there is no configuration.")
+	s[keyBucketReplication] = computed(schema.TypeString, "The replication configuration as JSON.\nEmpty if there is no configuration.")
+	s[keyBucketNotification] = computed(schema.TypeString, "The notification configuration as JSON.\nEmpty if there is no configuration.")
+
 	return &schema.Resource{
-		ReadContext:   resourceBucketRead,
-		Schema: schemaBucket(),
+		Description: "Looks up a bucket and its configuration.",
+		ReadContext: datasourceBucketRead,
+		Schema:      s,
+	}
+}
+
+// Check if an error only signals that an optional bucket configuration is not set.
+func isBucketConfigNotFound(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	if code == "" {
+		code = madmin.ToErrorResponse(err).Code
+	}
+	return stringSliceContains(bucketConfigNotFoundCodes, code)
+}
+
+// Marshal a bucket configuration to JSON.
+// Returns an empty string for empty configurations.
+func bucketConfigJSON(config interface{}, empty bool) (string, error) {
+	if empty {
+		return "", nil
+	}
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func datasourceBucketRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceBucketRead(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	name := d.Id()
+	client := m.(*minioContext).api
+	admin := m.(*minioContext).admin
+
+	values := make(map[string]interface{})
+
+	buckets, err := client.ListBuckets(ctx)
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	for _, bucket := range buckets {
+		if bucket.Name == name {
+			values[keyBucketCreationDate] = bucket.CreationDate.Format(time.RFC3339)
+		}
+	}
+
+	objectLock, mode, validity, unit, err := client.GetObjectLockConfig(ctx, name)
+	if err != nil && !isBucketConfigNotFound(err) {
+		return diag.Errorf("Could not load object lock configuration of bucket %s: %s", name, err)
+	}
+	values[keyBucketObjectLockEnabled] = objectLock == "Enabled"
+	values[keyBucketObjectLockMode] = ""
+	values[keyBucketObjectLockValidity] = 0
+	values[keyBucketObjectLockValidityUnit] = ""
+	if mode != nil && validity != nil && unit != nil {
+		values[keyBucketObjectLockMode] = mode.String()
+		values[keyBucketObjectLockValidity] = int(*validity)
+		values[keyBucketObjectLockValidityUnit] = unit.String()
+	}
+
+	encryption, err := client.GetBucketEncryption(ctx, name)
+	if err != nil && !isBucketConfigNotFound(err) {
+		return diag.Errorf("Could not load encryption configuration of bucket %s: %s", name, err)
+	}
+	values[keyBucketEncryptionAlgorithm] = ""
+	values[keyBucketEncryptionKMSKeyID] = ""
+	if encryption != nil && len(encryption.Rules) > 0 {
+		values[keyBucketEncryptionAlgorithm] = encryption.Rules[0].Apply.SSEAlgorithm
+		values[keyBucketEncryptionKMSKeyID] = encryption.Rules[0].Apply.KmsMasterKeyID
+	}
+
+	tags, err := client.GetBucketTagging(ctx, name)
+	if err != nil && !isBucketConfigNotFound(err) {
+		return diag.Errorf("Could not load tags of bucket %s: %s", name, err)
+	}
+	values[keyBucketTags] = map[string]string{}
+	if tags != nil {
+		values[keyBucketTags] = tags.ToMap()
+	}
+
+	// The quota is only available to admins, while everything else is
+	// readable with plain S3 permissions.
+	quota, err := admin.GetBucketQuota(ctx, name)
+	if err != nil && stringSliceContains(adminAccessDeniedCodes, madmin.ToErrorResponse(err).Code) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Quota of bucket %s is unknown: %s", name, err),
+		})
+		quota = madmin.BucketQuota{}
+	} else if err != nil && !isBucketConfigNotFound(err) {
+		return diag.Errorf("Could not load quota of bucket %s: %s", name, err)
+	}
+	values[keyBucketQuota] = int(quota.Quota)
+	values[keyBucketQuotaType] = string(quota.Type)
+
+	policy, err := client.GetBucketPolicy(ctx, name)
+	if err != nil {
+		return diag.Errorf("Could not load policy of bucket %s: %s", name, err)
+	}
+	values[keyBucketPolicy] = policy
+
+	lifecycle, err := client.GetBucketLifecycle(ctx, name)
+	if err != nil && !isBucketConfigNotFound(err) {
+		return diag.Errorf("Could not load lifecycle configuration of bucket %s: %s", name, err)
+	}
+	if values[keyBucketLifecycle], err = bucketConfigJSON(lifecycle, lifecycle == nil || lifecycle.Empty()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	replication, err := client.GetBucketReplication(ctx, name)
+	if err != nil {
+		return diag.Errorf("Could not load replication configuration of bucket %s: %s", name, err)
+	}
+	if values[keyBucketReplication], err = bucketConfigJSON(replication, replication.Empty()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	notification, err := client.GetBucketNotification(ctx, name)
+	if err != nil {
+		return diag.Errorf("Could not load notification configuration of bucket %s: %s", name, err)
+	}
+	notificationEmpty := len(notification.LambdaConfigs) == 0 && len(notification.TopicConfigs) == 0 && len(notification.QueueConfigs) == 0
+	if values[keyBucketNotification], err = bucketConfigJSON(notification, notificationEmpty); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := dataSetValues(d, values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
 }
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyBucketUsageBucket        = "bucket"
+	keyBucketUsageObjectCount   = "object_count"
+	keyBucketUsageVersionCount  = "version_count"
+	keyBucketUsageTotalSize     = "total_size"
+	keyBucketUsageSizeHistogram = "object_size_histogram"
+	keyBucketUsageLastUpdate    = "last_update"
+)
+
+func datasourceBucketUsage() *schema.Resource {
+	return &schema.Resource{
+		Description: "Usage figures of a bucket.\nThe figures are collected by the background scanner of the server, so they can lag behind recent changes.",
+		ReadContext: datasourceBucketUsageRead,
+		Schema: objectSchema{
+			keyBucketUsageBucket: &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the bucket.",
+			},
+			keyBucketUsageObjectCount: &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of objects in the bucket.",
+			},
+			keyBucketUsageVersionCount: &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of object versions in the bucket.",
+			},
+			keyBucketUsageTotalSize: &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total size of all objects in the bucket in bytes.",
+			},
+			keyBucketUsageSizeHistogram: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Computed:    true,
+				Description: "The number of objects per size range, keyed by the range, e.g. `BETWEEN_1024_B_AND_1_MB`.",
+			},
+			keyBucketUsageLastUpdate: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last usage scan in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func datasourceBucketUsageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	bucket := d.Get(keyBucketUsageBucket).(string)
+	client := m.(*minioContext).api
+	admin := m.(*minioContext).admin
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		return []diag.Diagnostic{diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Bucket %s does not exist", bucket),
+			AttributePath: cty.GetAttrPath(keyBucketUsageBucket),
+		}}
+	}
+
+	info, err := admin.DataUsageInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Could not load data usage info: %s", err)
+	}
+
+	usage, ok := info.BucketsUsage[bucket]
+	if !ok {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("No usage figures for bucket %s yet", bucket),
+			Detail:   "The bucket has not been scanned yet, all figures are reported as zero.",
+		})
+	}
+
+	histogram := make(map[string]interface{})
+	for sizeRange, count := range usage.ObjectSizesHistogram {
+		histogram[sizeRange] = int(count)
+	}
+
+	lastUpdate := ""
+	if !info.LastUpdate.IsZero() {
+		lastUpdate = info.LastUpdate.Format(time.RFC3339)
+	}
+
+	err = dataSetValues(d, map[string]interface{}{
+		keyBucketUsageObjectCount:   int(usage.ObjectsCount),
+		keyBucketUsageVersionCount:  int(usage.VersionsCount),
+		keyBucketUsageTotalSize:     int(usage.Size),
+		keyBucketUsageSizeHistogram: histogram,
+		keyBucketUsageLastUpdate:    lastUpdate,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(bucket)
+	return diags
+}
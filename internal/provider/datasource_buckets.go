@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyBucketsBuckets      = "buckets"
+	keyBucketsCreationDate = "creation_date"
+)
+
+func datasourceBuckets() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists all buckets, optionally filtered by name.",
+		ReadContext: datasourceBucketsRead,
+		Schema: schemaList(objectSchema{
+			keyBucketsBuckets: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching buckets, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keyBucketName: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyBucketsCreationDate: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func datasourceBucketsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+
+	buckets, err := client.ListBuckets(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var names []string
+	creationDates := make(map[string]time.Time)
+	for _, bucket := range buckets {
+		names = append(names, bucket.Name)
+		creationDates[bucket.Name] = bucket.CreationDate
+	}
+	names, err = dataFilterListNames(d, names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var summaries []interface{}
+	for _, name := range names {
+		summaries = append(summaries, map[string]interface{}{
+			keyBucketName:          name,
+			keyBucketsCreationDate: creationDates[name].Format(time.RFC3339),
+		})
+	}
+
+	if err := d.Set(keyListNames, names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyBucketsBuckets, summaries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dataListID("buckets", d))
+	return diags
+}
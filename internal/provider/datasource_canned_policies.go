@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyCannedPoliciesPolicies = "policies"
+)
+
+func datasourceCannedPolicies() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists all canned policies, optionally filtered by name.",
+		ReadContext: datasourceCannedPoliciesRead,
+		Schema: schemaList(objectSchema{
+			keyCannedPoliciesPolicies: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching policies, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keyPolicyName: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyPolicyPolicy: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func datasourceCannedPoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	policies, err := client.ListCannedPolicies(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var names []string
+	for name := range policies {
+		names = append(names, name)
+	}
+	names, err = dataFilterListNames(d, names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var summaries []interface{}
+	for _, name := range names {
+		summaries = append(summaries, map[string]interface{}{
+			keyPolicyName:   name,
+			keyPolicyPolicy: string(policies[name]),
+		})
+	}
+
+	if err := d.Set(keyListNames, names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyCannedPoliciesPolicies, summaries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dataListID("canned_policies", d))
+	return diags
+}
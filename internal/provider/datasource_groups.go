@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyGroupsGroups = "groups"
+)
+
+func datasourceGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists all groups, optionally filtered by name.",
+		ReadContext: datasourceGroupsRead,
+		Schema: schemaList(objectSchema{
+			keyGroupsGroups: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching groups, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keyGroupName: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyGroupEnabled: &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						keyGroupPolicies: &schema.Schema{
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						keyGroupMembers: &schema.Schema{
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func datasourceGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	groupNames, err := m.(*minioContext).listGroups(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupNames, err = dataFilterListNames(d, groupNames)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var summaries []interface{}
+	for _, groupName := range groupNames {
+		info, err := client.GetGroupDescription(ctx, groupName)
+		if err != nil {
+			return diag.Errorf("Could not load group %s: %s", groupName, err)
+		}
+		summaries = append(summaries, map[string]interface{}{
+			keyGroupName:     groupName,
+			keyGroupEnabled:  info.Status == string(madmin.GroupEnabled),
+			keyGroupPolicies: splitPolicyNames(info.Policy),
+			keyGroupMembers:  stringSliceSorted(info.Members),
+		})
+	}
+
+	if err := d.Set(keyListNames, groupNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyGroupsGroups, summaries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dataListID("groups", d))
+	return diags
+}
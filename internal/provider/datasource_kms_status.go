@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyKMSStatusName            = "name"
+	keyKMSStatusDefaultKeyID    = "default_key_id"
+	keyKMSStatusEndpoints       = "endpoints"
+	keyKMSStatusKeyID           = "key_id"
+	keyKMSStatusEncryptionError = "encryption_error"
+	keyKMSStatusDecryptionError = "decryption_error"
+)
+
+func datasourceKMSStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "The status of the KMS configured for the server, and of one of its keys.",
+		ReadContext: datasourceKMSStatusRead,
+		Schema: objectSchema{
+			keyKMSStatusName: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name or type of the KMS.",
+			},
+			keyKMSStatusDefaultKeyID: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key used when no key is specified explicitly.",
+			},
+			keyKMSStatusEndpoints: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The state of the KMS endpoints, e.g. `online`, keyed by endpoint.",
+			},
+			keyKMSStatusKeyID: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key to check. Defaults to the default key.",
+			},
+			keyKMSStatusEncryptionError: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error when encrypting with the key. Empty if encryption works.",
+			},
+			keyKMSStatusDecryptionError: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error when decrypting with the key. Empty if decryption works.",
+			},
+		},
+	}
+}
+
+func datasourceKMSStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	status, err := client.KMSStatus(ctx)
+	if err != nil {
+		return diag.Errorf("Could not load KMS status: %s", err)
+	}
+
+	keyID := d.Get(keyKMSStatusKeyID).(string)
+	if keyID == "" {
+		keyID = status.DefaultKeyID
+	}
+	keyStatus, err := client.GetKeyStatus(ctx, keyID)
+	if err != nil {
+		return diag.Errorf("Could not load status of key %s: %s", keyID, err)
+	}
+
+	endpoints := make(map[string]string)
+	for endpoint, state := range status.Endpoints {
+		endpoints[endpoint] = string(state)
+	}
+
+	err = dataSetValues(d, map[string]interface{}{
+		keyKMSStatusName:            status.Name,
+		keyKMSStatusDefaultKeyID:    status.DefaultKeyID,
+		keyKMSStatusEndpoints:       endpoints,
+		keyKMSStatusEncryptionError: keyStatus.EncryptionErr,
+		keyKMSStatusDecryptionError: keyStatus.DecryptionErr,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(status.Name + "/" + keyID)
+	return diags
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Shared functionality for the data sources that list all entities of a kind.
+
+const (
+	keyListNameRegex = "name_regex"
+	keyListPrefix    = "prefix"
+	keyListNames     = "names"
+)
+
+// Build the schema of a listing data source.
+// Adds the filter attributes and the list of names to the given attributes.
+func schemaList(s objectSchema) objectSchema {
+	s[keyListNameRegex] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Only include entries with a name that matches this regular expression.",
+		ValidateFunc: validation.StringIsValidRegExp,
+	}
+	s[keyListPrefix] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Only include entries with a name that starts with this prefix.",
+	}
+	s[keyListNames] = &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Computed:    true,
+		Description: "The sorted names of all matching entries.",
+	}
+	return s
+}
+
+// Apply the name filters of a listing data source.
+// Returns the sorted names that match all filters.
+func dataFilterListNames(d *schema.ResourceData, names []string) ([]string, error) {
+	prefix := d.Get(keyListPrefix).(string)
+	var nameRegex *regexp.Regexp
+	if raw := d.Get(keyListNameRegex).(string); raw != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	filtered := []string{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		filtered = append(filtered, name)
+	}
+	sort.Strings(filtered)
+	return filtered, nil
+}
+
+// Build the ID of a listing data source, which is derived from the filters.
+func dataListID(kind string, d *schema.ResourceData) string {
+	return kind + "/" + d.Get(keyListPrefix).(string) + "/" + d.Get(keyListNameRegex).(string)
+}
+
+// Like stringSliceFilterEmpty, but sorts the result for stable output.
+func stringSliceSorted(slice []string) []string {
+	sorted := stringSliceFilterEmpty(slice)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyPresignedURLBucket           = "bucket"
+	keyPresignedURLKey              = "key"
+	keyPresignedURLMethod           = "method"
+	keyPresignedURLExpiresIn        = "expires_in"
+	keyPresignedURLResponseHeaders  = "response_headers"
+	keyPresignedURLKeyPrefix        = "key_prefix"
+	keyPresignedURLContentLengthMin = "content_length_min"
+	keyPresignedURLContentLengthMax = "content_length_max"
+	keyPresignedURLURL              = "url"
+	keyPresignedURLFormFields       = "form_fields"
+	keyPresignedURLExpiration       = "expiration"
+)
+
+// The maximum validity of presigned URLs allowed by S3.
+const presignedURLMaxExpiresIn = 7 * 24 * 60 * 60
+
+// Response headers that can be overridden for GET and HEAD requests.
+var presignedURLResponseHeaders = []string{
+	"cache-control",
+	"content-disposition",
+	"content-encoding",
+	"content-language",
+	"content-type",
+	"expires",
+}
+
+func datasourcePresignedURL() *schema.Resource {
+	return &schema.Resource{
+		Description: "Generates a presigned URL for an object, or a presigned POST policy for browser uploads.\nA new URL is generated on every read, so it changes on every plan.",
+		ReadContext: datasourcePresignedURLRead,
+		Schema: objectSchema{
+			keyPresignedURLBucket: &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the bucket.",
+			},
+			keyPresignedURLKey: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key of the object.\nRequired unless `method` is `POST` and `key_prefix` is set.",
+			},
+			keyPresignedURLMethod: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GET",
+				Description:  "The HTTP method the URL is valid for: `GET`, `PUT`, `HEAD` or `POST`.",
+				ValidateFunc: validation.StringInSlice([]string{"GET", "PUT", "HEAD", "POST"}, false),
+			},
+			keyPresignedURLExpiresIn: &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				Description:  "The validity of the URL in seconds. At most 7 days.",
+				ValidateFunc: validation.IntBetween(1, presignedURLMaxExpiresIn),
+			},
+			keyPresignedURLResponseHeaders: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "Overrides for response headers, keyed by header name, e.g. `Content-Disposition`.\nOnly for `GET` and `HEAD`. Supported headers are " + strings.Join(presignedURLResponseHeaders, ", ") + ".",
+			},
+			keyPresignedURLKeyPrefix: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only for `POST`: allow uploads of any key with this prefix instead of a fixed `key`.",
+			},
+			keyPresignedURLContentLengthMin: &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Only for `POST`: the minimum size of the upload in bytes.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			keyPresignedURLContentLengthMax: &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Only for `POST`: the maximum size of the upload in bytes.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			keyPresignedURLURL: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The presigned URL.",
+			},
+			keyPresignedURLFormFields: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Only for `POST`: the form fields that must be sent along with the upload.",
+			},
+			keyPresignedURLExpiration: &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the URL expires in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func presignedURLAttributeError(key string, summary string) diag.Diagnostics {
+	return []diag.Diagnostic{diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       summary,
+		AttributePath: cty.GetAttrPath(key),
+	}}
+}
+
+// Build the query parameters for the response header overrides.
+func presignedURLResponseParams(headers map[string]interface{}) (url.Values, error) {
+	params := make(url.Values)
+	for name, value := range headers {
+		name = strings.ToLower(name)
+		if !stringSliceContains(presignedURLResponseHeaders, name) {
+			return nil, fmt.Errorf("Unsupported response header %s", name)
+		}
+		params.Set("response-"+name, value.(string))
+	}
+	return params, nil
+}
+
+// Build the POST policy.
+func presignedURLPostPolicy(d *schema.ResourceData, bucket string, key string, expiration time.Time) (*minio.PostPolicy, error) {
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(bucket); err != nil {
+		return nil, err
+	}
+	if err := policy.SetExpires(expiration); err != nil {
+		return nil, err
+	}
+	if key != "" {
+		if err := policy.SetKey(key); err != nil {
+			return nil, err
+		}
+	} else if err := policy.SetKeyStartsWith(d.Get(keyPresignedURLKeyPrefix).(string)); err != nil {
+		return nil, err
+	}
+	minLength := d.Get(keyPresignedURLContentLengthMin).(int)
+	maxLength := d.Get(keyPresignedURLContentLengthMax).(int)
+	if maxLength > 0 {
+		if err := policy.SetContentLengthRange(int64(minLength), int64(maxLength)); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+func datasourcePresignedURLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyPresignedURLBucket).(string)
+	key := d.Get(keyPresignedURLKey).(string)
+	method := d.Get(keyPresignedURLMethod).(string)
+	expiresIn := time.Duration(d.Get(keyPresignedURLExpiresIn).(int)) * time.Second
+	headers := d.Get(keyPresignedURLResponseHeaders).(map[string]interface{})
+	keyPrefix := d.Get(keyPresignedURLKeyPrefix).(string)
+	minLength := d.Get(keyPresignedURLContentLengthMin).(int)
+	maxLength := d.Get(keyPresignedURLContentLengthMax).(int)
+
+	// Check which attributes are allowed for the method.
+	if method == "POST" {
+		if key != "" && keyPrefix != "" {
+			return presignedURLAttributeError(keyPresignedURLKeyPrefix, "key and key_prefix can not both be set")
+		}
+		if key == "" && keyPrefix == "" {
+			return presignedURLAttributeError(keyPresignedURLKey, "Either key or key_prefix is required for POST")
+		}
+		if minLength > 0 && maxLength == 0 {
+			return presignedURLAttributeError(keyPresignedURLContentLengthMax, "content_length_max is required if content_length_min is set")
+		}
+		if maxLength > 0 && minLength > maxLength {
+			return presignedURLAttributeError(keyPresignedURLContentLengthMin, "content_length_min must not be larger than content_length_max")
+		}
+	} else {
+		if key == "" {
+			return presignedURLAttributeError(keyPresignedURLKey, "key is required for "+method)
+		}
+		for _, postOnly := range []string{keyPresignedURLKeyPrefix, keyPresignedURLContentLengthMin, keyPresignedURLContentLengthMax} {
+			if _, ok := d.GetOk(postOnly); ok {
+				return presignedURLAttributeError(postOnly, postOnly+" is only supported for POST")
+			}
+		}
+	}
+	if len(headers) > 0 && method != "GET" && method != "HEAD" {
+		return presignedURLAttributeError(keyPresignedURLResponseHeaders, "response_headers are only supported for GET and HEAD")
+	}
+
+	expiration := time.Now().Add(expiresIn)
+	var presigned *url.URL
+	formFields := map[string]string{}
+	var err error
+	switch method {
+	case "GET", "HEAD":
+		params, paramsErr := presignedURLResponseParams(headers)
+		if paramsErr != nil {
+			return presignedURLAttributeError(keyPresignedURLResponseHeaders, paramsErr.Error())
+		}
+		if method == "GET" {
+			presigned, err = client.PresignedGetObject(ctx, bucket, key, expiresIn, params)
+		} else {
+			presigned, err = client.PresignedHeadObject(ctx, bucket, key, expiresIn, params)
+		}
+	case "PUT":
+		presigned, err = client.PresignedPutObject(ctx, bucket, key, expiresIn)
+	case "POST":
+		policy, policyErr := presignedURLPostPolicy(d, bucket, key, expiration)
+		if policyErr != nil {
+			return diag.FromErr(policyErr)
+		}
+		presigned, formFields, err = client.PresignedPostPolicy(ctx, policy)
+	}
+	if err != nil {
+		return diag.Errorf("Could not presign %s request: %s", method, err)
+	}
+
+	err = dataSetValues(d, map[string]interface{}{
+		keyPresignedURLURL:        presigned.String(),
+		keyPresignedURLFormFields: formFields,
+		keyPresignedURLExpiration: expiration.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(method + "/" + bucket + "/" + key + keyPrefix)
+	return diags
+}
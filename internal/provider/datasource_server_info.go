@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyServerInfoDeploymentID     = "deployment_id"
+	keyServerInfoRegion           = "region"
+	keyServerInfoMode             = "mode"
+	keyServerInfoBackendType      = "backend_type"
+	keyServerInfoOnlineDrives     = "online_drives"
+	keyServerInfoOfflineDrives    = "offline_drives"
+	keyServerInfoStandardSCParity = "standard_sc_parity"
+	keyServerInfoRRSCParity       = "rrsc_parity"
+	keyServerInfoTotalCapacity    = "total_capacity"
+	keyServerInfoUsedCapacity     = "used_capacity"
+	keyServerInfoFreeCapacity     = "free_capacity"
+	keyServerInfoErasureSets      = "erasure_sets"
+	keyServerInfoServers          = "servers"
+
+	keyErasureSetPool   = "pool"
+	keyErasureSetSet    = "set"
+	keyErasureSetDrives = "drives"
+
+	keyServerEndpoint = "endpoint"
+	keyServerState    = "state"
+	keyServerVersion  = "version"
+	keyServerCommitID = "commit_id"
+	keyServerUptime   = "uptime"
+	keyServerPool     = "pool"
+)
+
+func datasourceServerInfo() *schema.Resource {
+	computedInt := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: description,
+		}
+	}
+	computedString := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Information about the deployment and the health of its storage.",
+		ReadContext: datasourceServerInfoRead,
+		Schema: objectSchema{
+			keyServerInfoDeploymentID:     computedString("The unique ID of the deployment."),
+			keyServerInfoRegion:           computedString("The configured region."),
+			keyServerInfoMode:             computedString("The mode of the deployment, e.g. `online`."),
+			keyServerInfoBackendType:      computedString("The storage backend: `Erasure`, `FS`, `Gateway` or `Unknown`."),
+			keyServerInfoOnlineDrives:     computedInt("The number of drives that are online."),
+			keyServerInfoOfflineDrives:    computedInt("The number of drives that are offline or otherwise not healthy."),
+			keyServerInfoStandardSCParity: computedInt("The number of parity drives of the standard storage class."),
+			keyServerInfoRRSCParity:       computedInt("The number of parity drives of the reduced redundancy storage class."),
+			keyServerInfoTotalCapacity:    computedInt("The total capacity of all drives in bytes."),
+			keyServerInfoUsedCapacity:     computedInt("The used capacity of all drives in bytes."),
+			keyServerInfoFreeCapacity:     computedInt("The available capacity of all drives in bytes."),
+			keyServerInfoErasureSets: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The erasure sets, sorted by pool and set index.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keyErasureSetPool:          computedInt("The index of the pool."),
+						keyErasureSetSet:           computedInt("The index of the set within the pool."),
+						keyErasureSetDrives:        computedInt("The number of drives in the set."),
+						keyServerInfoOnlineDrives:  computedInt("The number of drives in the set that are online."),
+						keyServerInfoOfflineDrives: computedInt("The number of drives in the set that are not online."),
+					},
+				},
+			},
+			keyServerInfoServers: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The nodes of the deployment.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keyServerEndpoint:          computedString("The endpoint of the node."),
+						keyServerState:             computedString("The state of the node, e.g. `online`."),
+						keyServerVersion:           computedString("The MinIO version of the node."),
+						keyServerCommitID:          computedString("The commit ID of the MinIO version of the node."),
+						keyServerUptime:            computedInt("The uptime of the node in seconds."),
+						keyServerPool:              computedInt("The pool number of the node."),
+						keyServerInfoOnlineDrives:  computedInt("The number of drives of the node that are online."),
+						keyServerInfoOfflineDrives: computedInt("The number of drives of the node that are not online."),
+					},
+				},
+			},
+		},
+	}
+}
+
+// Count the healthy and unhealthy drives.
+func countDrives(disks []madmin.Disk) (online int, offline int) {
+	for _, disk := range disks {
+		if disk.State == madmin.DriveStateOk {
+			online++
+		} else {
+			offline++
+		}
+	}
+	return online, offline
+}
+
+func backendTypeName(t madmin.BackendType) string {
+	switch t {
+	case madmin.FS:
+		return "FS"
+	case madmin.Erasure:
+		return "Erasure"
+	case madmin.Gateway:
+		return "Gateway"
+	default:
+		return "Unknown"
+	}
+}
+
+func datasourceServerInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	info, err := client.ServerInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Could not load server info: %s", err)
+	}
+	storage, err := client.StorageInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Could not load storage info: %s", err)
+	}
+
+	var totalCapacity, usedCapacity, freeCapacity uint64
+	type setIndex struct{ pool, set int }
+	sets := make(map[setIndex][]madmin.Disk)
+	for _, disk := range storage.Disks {
+		totalCapacity += disk.TotalSpace
+		usedCapacity += disk.UsedSpace
+		freeCapacity += disk.AvailableSpace
+		// Drives that are not assigned to a set yet have negative indexes.
+		if disk.PoolIndex >= 0 && disk.SetIndex >= 0 {
+			index := setIndex{disk.PoolIndex, disk.SetIndex}
+			sets[index] = append(sets[index], disk)
+		}
+	}
+
+	var setIndexes []setIndex
+	for index := range sets {
+		setIndexes = append(setIndexes, index)
+	}
+	sort.Slice(setIndexes, func(i, j int) bool {
+		if setIndexes[i].pool != setIndexes[j].pool {
+			return setIndexes[i].pool < setIndexes[j].pool
+		}
+		return setIndexes[i].set < setIndexes[j].set
+	})
+	var erasureSets []interface{}
+	for _, index := range setIndexes {
+		online, offline := countDrives(sets[index])
+		erasureSets = append(erasureSets, map[string]interface{}{
+			keyErasureSetPool:          index.pool,
+			keyErasureSetSet:           index.set,
+			keyErasureSetDrives:        len(sets[index]),
+			keyServerInfoOnlineDrives:  online,
+			keyServerInfoOfflineDrives: offline,
+		})
+	}
+
+	var servers []interface{}
+	for _, server := range info.Servers {
+		online, offline := countDrives(server.Disks)
+		servers = append(servers, map[string]interface{}{
+			keyServerEndpoint:          server.Endpoint,
+			keyServerState:             server.State,
+			keyServerVersion:           server.Version,
+			keyServerCommitID:          server.CommitID,
+			keyServerUptime:            int(server.Uptime),
+			keyServerPool:              server.PoolNumber,
+			keyServerInfoOnlineDrives:  online,
+			keyServerInfoOfflineDrives: offline,
+		})
+	}
+
+	online, offline := countDrives(storage.Disks)
+
+	err = dataSetValues(d, map[string]interface{}{
+		keyServerInfoDeploymentID:     info.DeploymentID,
+		keyServerInfoRegion:           info.Region,
+		keyServerInfoMode:             info.Mode,
+		keyServerInfoBackendType:      backendTypeName(storage.Backend.Type),
+		keyServerInfoOnlineDrives:     online,
+		keyServerInfoOfflineDrives:    offline,
+		keyServerInfoStandardSCParity: storage.Backend.StandardSCParity,
+		keyServerInfoRRSCParity:       storage.Backend.RRSCParity,
+		keyServerInfoTotalCapacity:    int(totalCapacity),
+		keyServerInfoUsedCapacity:     int(usedCapacity),
+		keyServerInfoFreeCapacity:     int(freeCapacity),
+		keyServerInfoErasureSets:      erasureSets,
+		keyServerInfoServers:          servers,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(info.DeploymentID)
+	return diags
+}
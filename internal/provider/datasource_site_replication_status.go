@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keySiteReplicationStatusEnabled = "enabled"
+	keySiteReplicationStatusInSync  = "in_sync"
+	keySiteReplicationStatusSites   = "sites"
+
+	keySiteStatusReplicatedBuckets  = "replicated_buckets"
+	keySiteStatusTotalBuckets       = "total_buckets"
+	keySiteStatusReplicatedUsers    = "replicated_users"
+	keySiteStatusTotalUsers         = "total_users"
+	keySiteStatusReplicatedGroups   = "replicated_groups"
+	keySiteStatusTotalGroups        = "total_groups"
+	keySiteStatusReplicatedPolicies = "replicated_policies"
+	keySiteStatusTotalPolicies      = "total_policies"
+	keySiteStatusErrors             = "errors"
+)
+
+func datasourceSiteReplicationStatus() *schema.Resource {
+	computedInt := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: description,
+		}
+	}
+	computedString := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "The sync state of site replication, like `mc admin replicate status`.",
+		ReadContext: datasourceSiteReplicationStatusRead,
+		Schema: objectSchema{
+			keySiteReplicationStatusEnabled: &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if site replication is set up.",
+			},
+			keySiteReplicationStatusInSync: &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if no site reports any errors.",
+			},
+			keySiteReplicationStatusSites: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The replicated sites, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keySiteName:                     computedString("The name of the site."),
+						keySiteEndpoint:                 computedString("The endpoint of the site."),
+						keySiteDeploymentID:             computedString("The deployment ID of the site."),
+						keySiteStatusReplicatedBuckets:  computedInt("The number of buckets replicated across all sites."),
+						keySiteStatusTotalBuckets:       computedInt("The number of buckets on the site."),
+						keySiteStatusReplicatedUsers:    computedInt("The number of users replicated across all sites."),
+						keySiteStatusTotalUsers:         computedInt("The number of users on the site."),
+						keySiteStatusReplicatedGroups:   computedInt("The number of groups replicated across all sites."),
+						keySiteStatusTotalGroups:        computedInt("The number of groups on the site."),
+						keySiteStatusReplicatedPolicies: computedInt("The number of IAM policies replicated across all sites."),
+						keySiteStatusTotalPolicies:      computedInt("The number of IAM policies on the site."),
+						keySiteStatusErrors: &schema.Schema{
+							Type: schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Computed:    true,
+							Description: "The entities that are out of sync on the site, e.g. `bucket photos: tags mismatch`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Collect the sync errors of all sites, keyed by deployment ID.
+func siteReplicationErrors(info madmin.SRStatusInfo) map[string][]string {
+	result := make(map[string][]string)
+	add := func(deploymentID string, entity string, problem string, failed bool) {
+		if failed {
+			result[deploymentID] = append(result[deploymentID], fmt.Sprintf("%s: %s", entity, problem))
+		}
+	}
+
+	for bucket, stats := range info.BucketStats {
+		entity := "bucket " + bucket
+		for _, s := range stats {
+			add(s.DeploymentID, entity, "missing", !s.HasBucket && !s.BucketMarkedDeleted)
+			add(s.DeploymentID, entity, "tags mismatch", s.TagMismatch)
+			add(s.DeploymentID, entity, "versioning mismatch", s.VersioningConfigMismatch)
+			add(s.DeploymentID, entity, "object lock mismatch", s.OLockConfigMismatch)
+			add(s.DeploymentID, entity, "policy mismatch", s.PolicyMismatch)
+			add(s.DeploymentID, entity, "encryption mismatch", s.SSEConfigMismatch)
+			add(s.DeploymentID, entity, "replication mismatch", s.ReplicationCfgMismatch)
+			add(s.DeploymentID, entity, "quota mismatch", s.QuotaCfgMismatch)
+		}
+	}
+	for policy, stats := range info.PolicyStats {
+		entity := "policy " + policy
+		for _, s := range stats {
+			add(s.DeploymentID, entity, "missing", !s.HasPolicy)
+			add(s.DeploymentID, entity, "mismatch", s.PolicyMismatch)
+		}
+	}
+	for user, stats := range info.UserStats {
+		entity := "user " + user
+		for _, s := range stats {
+			add(s.DeploymentID, entity, "missing", !s.HasUser)
+			add(s.DeploymentID, entity, "info mismatch", s.UserInfoMismatch)
+			add(s.DeploymentID, entity, "policy mismatch", s.PolicyMismatch)
+		}
+	}
+	for group, stats := range info.GroupStats {
+		entity := "group " + group
+		for _, s := range stats {
+			add(s.DeploymentID, entity, "missing", !s.HasGroup)
+			add(s.DeploymentID, entity, "description mismatch", s.GroupDescMismatch)
+			add(s.DeploymentID, entity, "policy mismatch", s.PolicyMismatch)
+		}
+	}
+
+	for _, errors := range result {
+		sort.Strings(errors)
+	}
+	return result
+}
+
+func datasourceSiteReplicationStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	info, err := client.SRStatusInfo(ctx, madmin.SRStatusOptions{
+		Buckets:  true,
+		Policies: true,
+		Users:    true,
+		Groups:   true,
+	})
+	if err != nil {
+		return diag.Errorf("Could not load site replication status: %s", err)
+	}
+
+	errors := siteReplicationErrors(info)
+	inSync := true
+	var sites []interface{}
+	for deploymentID, peer := range info.Sites {
+		summary := info.StatsSummary[deploymentID]
+		if len(errors[deploymentID]) > 0 {
+			inSync = false
+		}
+		sites = append(sites, map[string]interface{}{
+			keySiteName:                     peer.Name,
+			keySiteEndpoint:                 peer.Endpoint,
+			keySiteDeploymentID:             deploymentID,
+			keySiteStatusReplicatedBuckets:  summary.ReplicatedBuckets,
+			keySiteStatusTotalBuckets:       summary.TotalBucketsCount,
+			keySiteStatusReplicatedUsers:    summary.ReplicatedUsers,
+			keySiteStatusTotalUsers:         summary.TotalUsersCount,
+			keySiteStatusReplicatedGroups:   summary.ReplicatedGroups,
+			keySiteStatusTotalGroups:        summary.TotalGroupsCount,
+			keySiteStatusReplicatedPolicies: summary.ReplicatedIAMPolicies,
+			keySiteStatusTotalPolicies:      summary.TotalIAMPoliciesCount,
+			keySiteStatusErrors:             errors[deploymentID],
+		})
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].(map[string]interface{})[keySiteName].(string) < sites[j].(map[string]interface{})[keySiteName].(string)
+	})
+
+	d.SetId("site-replication-status")
+	if err := dataSetValues(d, map[string]interface{}{
+		keySiteReplicationStatusEnabled: info.Enabled,
+		keySiteReplicationStatusInSync:  inSync,
+		keySiteReplicationStatusSites:   sites,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
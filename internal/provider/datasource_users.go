@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyUsersUsers = "users"
+)
+
+func datasourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists all users, optionally filtered by name.",
+		ReadContext: datasourceUsersRead,
+		Schema: schemaList(objectSchema{
+			keyUsersUsers: &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching users, sorted by access key.",
+				Elem: &schema.Resource{
+					Schema: objectSchema{
+						keyAccessKey: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyUserEnabled: &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						keyUserPolicies: &schema.Schema{
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						keyUserGroups: &schema.Schema{
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func datasourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	users, err := m.(*minioContext).listUsers(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var accessKeys []string
+	for accessKey := range users {
+		accessKeys = append(accessKeys, accessKey)
+	}
+	accessKeys, err = dataFilterListNames(d, accessKeys)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var summaries []interface{}
+	for _, accessKey := range accessKeys {
+		user := users[accessKey]
+		summaries = append(summaries, map[string]interface{}{
+			keyAccessKey:    accessKey,
+			keyUserEnabled:  user.Status == madmin.AccountEnabled,
+			keyUserPolicies: splitPolicyNames(user.PolicyName),
+			keyUserGroups:   stringSliceSorted(user.MemberOf),
+		})
+	}
+
+	if err := d.Set(keyListNames, accessKeys); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyUsersUsers, summaries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dataListID("users", d))
+	return diags
+}
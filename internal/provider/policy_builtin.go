@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// Newer minio servers expose the "policy builtin" admin API, which allows
+// attaching and detaching individual policies to users and groups.
+// The same API exists for LDAP users and groups, addressed by their DN.
+// It is not supported by the admin client SDK version we use, so the
+// requests are issued manually here.
+
+const (
+	policyOperationAttach = "attach"
+	policyOperationDetach = "detach"
+)
+
+// Identity providers of the policy attach API.
+const (
+	policyIDPBuiltin = "builtin"
+	policyIDPLDAP    = "ldap"
+)
+
+// Support state of the policy builtin API, determined on first use.
+const (
+	policyBuiltinUnknown = iota
+	policyBuiltinSupported
+	policyBuiltinUnsupported
+)
+
+var errPolicyBuiltinUnsupported = errors.New("The minio server does not support the policy builtin API")
+
+type policyAssociationRequest struct {
+	Policies []string `json:"policies"`
+	User     string   `json:"user,omitempty"`
+	Group    string   `json:"group,omitempty"`
+}
+
+// Attach or detach policies to/from a user or group of an identity provider
+// with the policy builtin API.
+// Returns errPolicyBuiltinUnsupported if the server does not know the API.
+func (c *minioContext) policyBuiltinUpdate(ctx context.Context, idp string, operation string, request policyAssociationRequest) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	content, err := madmin.EncryptData(c.secretKey, payload)
+	if err != nil {
+		return err
+	}
+
+	url := c.endpointURL + "/minio/admin/v3/idp/" + idp + "/policy/" + operation
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(content)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	req.ContentLength = int64(len(content))
+	req = signer.SignV4(*req, c.accessKey, c.secretKey, "", "")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var errResp madmin.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		errResp.Code = resp.Status
+		errResp.Message = string(body)
+	}
+
+	switch {
+	// Servers without the API reject unknown admin routes.
+	case resp.StatusCode == http.StatusUpgradeRequired,
+		resp.StatusCode == http.StatusNotFound,
+		resp.StatusCode == http.StatusMethodNotAllowed,
+		errResp.Code == "XMinioAdminVersionMismatch":
+		return errPolicyBuiltinUnsupported
+	// The desired state is already present.
+	case errResp.Code == "XMinioAdminPolicyChangeAlreadyApplied":
+		return nil
+	}
+	return fmt.Errorf("%s: %s", errResp.Code, errResp.Message)
+}
+
+// Change the policies of a user or group from the old to the new set.
+// Uses attach/detach deltas if the server supports it, and falls back to
+// replacing the whole policy list via SetPolicy otherwise.
+// Must be called with the policy lock held.
+func (c *minioContext) updateEntityPolicies(ctx context.Context, entityName string, isGroup bool, old []string, _new []string) error {
+	return c.updateIDPEntityPolicies(ctx, policyIDPBuiltin, &c.policyBuiltinSupport, entityName, isGroup, old, _new)
+}
+
+// Change the policies of an LDAP user or group, identified by its DN, from
+// the old to the new set.
+// Must be called with the policy lock held.
+func (c *minioContext) updateLDAPEntityPolicies(ctx context.Context, dn string, isGroup bool, old []string, _new []string) error {
+	return c.updateIDPEntityPolicies(ctx, policyIDPLDAP, &c.policyLDAPSupport, dn, isGroup, old, _new)
+}
+
+// Change the policies of a user or group of an identity provider.
+// The support state of the attach API for the provider is tracked in support.
+func (c *minioContext) updateIDPEntityPolicies(ctx context.Context, idp string, support *int, entityName string, isGroup bool, old []string, _new []string) error {
+	added, removed := stringSliceDiff(old, _new)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	if *support != policyBuiltinUnsupported {
+		err := c.policyBuiltinApply(ctx, idp, entityName, isGroup, added, removed)
+		if err == nil {
+			*support = policyBuiltinSupported
+			return nil
+		}
+		if err != errPolicyBuiltinUnsupported {
+			return err
+		}
+		log.Printf("[DEBUG] Policy attach API for %s not supported by server, falling back to SetPolicy\n", idp)
+		*support = policyBuiltinUnsupported
+	}
+
+	if len(_new) == 0 {
+		entityType := "user"
+		if isGroup {
+			entityType = "group"
+		}
+		return fmt.Errorf("Can not set policies to empty after the %s has been assigned other policies. This is a minio API limitation on servers without the policy builtin API.", entityType)
+	}
+	return c.admin.SetPolicy(ctx, strings.Join(_new, ","), entityName, isGroup)
+}
+
+func (c *minioContext) policyBuiltinApply(ctx context.Context, idp string, entityName string, isGroup bool, added []string, removed []string) error {
+	request := policyAssociationRequest{}
+	if isGroup {
+		request.Group = entityName
+	} else {
+		request.User = entityName
+	}
+
+	// Attach first, so the entity never ends up without any policy in between.
+	if len(added) > 0 {
+		request.Policies = added
+		if err := c.policyBuiltinUpdate(ctx, idp, policyOperationAttach, request); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		request.Policies = removed
+		if err := c.policyBuiltinUpdate(ctx, idp, policyOperationDetach, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
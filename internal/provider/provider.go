@@ -2,6 +2,9 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7"
@@ -14,6 +17,7 @@ import (
 const (
 	keyConfigEndpoint = "endpoint"
 	keyConfigSsl      = "ssl"
+	keyConfigStrict   = "strict"
 )
 
 func init() {
@@ -48,6 +52,11 @@ func NewMinioProvider() *schema.Provider {
 				Optional:    true,
 				Description: "If true, https:// will be used.",
 			},
+			keyConfigStrict: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If true, the creation of users and groups is rolled back if any step after the creation fails, and an error is returned instead of a warning.\nCan also be enabled per resource.",
+			},
 			keyAccessKey: &schema.Schema{
 				Type:        schema.TypeString,
 				Sensitive:   true,
@@ -67,12 +76,48 @@ func NewMinioProvider() *schema.Provider {
 			"minio_user":          resourceUser(),
 			"minio_canned_policy": resourceCannedPolicy(),
 			"minio_group":         resourceGroup(),
+
+			"minio_user_policy_attachment":  resourceUserPolicyAttachment(),
+			"minio_group_policy_attachment": resourceGroupPolicyAttachment(),
+			"minio_group_membership":        resourceGroupMembership(),
+
+			"minio_bucket_sync":       resourceBucketSync(),
+			"minio_object_copy":       resourceObjectCopy(),
+			"minio_object_governance": resourceObjectGovernance(),
+
+			"minio_kms_key":  resourceKMSKey(),
+			"minio_ilm_tier": resourceILMTier(),
+
+			"minio_server_config":  resourceServerConfig(),
+			"minio_notify_webhook": resourceNotifyWebhook(),
+			"minio_notify_kafka":   resourceNotifyKafka(),
+			"minio_notify_amqp":    resourceNotifyAMQP(),
+			"minio_audit_webhook":  resourceAuditWebhook(),
+			"minio_logger_webhook": resourceLoggerWebhook(),
+
+			"minio_openid_provider":     resourceOpenIDProvider(),
+			"minio_ldap_config":         resourceLDAPConfig(),
+			"minio_ldap_policy_mapping": resourceLDAPPolicyMapping(),
+
+			"minio_site_replication": resourceSiteReplication(),
 		},
         DataSourcesMap: map[string]*schema.Resource{
             "minio_bucket": datasourceBucket(),
             "minio_user": datasourceUser(),
             "minio_canned_policy": datasourceCannedPolicy(),
 			"minio_group":         datasourceGroup(),
+
+			"minio_users":           datasourceUsers(),
+			"minio_groups":          datasourceGroups(),
+			"minio_canned_policies": datasourceCannedPolicies(),
+			"minio_buckets":         datasourceBuckets(),
+
+			"minio_server_info":   datasourceServerInfo(),
+			"minio_bucket_usage":  datasourceBucketUsage(),
+			"minio_presigned_url": datasourcePresignedURL(),
+			"minio_kms_status":    datasourceKMSStatus(),
+
+			"minio_site_replication_status": datasourceSiteReplicationStatus(),
         },
 	}
 }
@@ -80,6 +125,32 @@ func NewMinioProvider() *schema.Provider {
 type minioContext struct {
 	api   *minio.Client
 	admin *madmin.AdminClient
+
+	// Connection details, required for admin API calls not covered by the
+	// admin client. httpClient shares the transport of the admin client.
+	endpointURL string
+	accessKey   string
+	secretKey   string
+	httpClient  *http.Client
+
+	// Guards read-modify-write cycles on the policies of users and groups,
+	// as well as policyBuiltinSupport and policyLDAPSupport.
+	policyMutex          sync.Mutex
+	policyBuiltinSupport int
+	policyLDAPSupport    int
+
+	// Cached results of listing calls.
+	cache listCache
+
+	// Roll back partially created resources on failure.
+	strict bool
+}
+
+// Acquire the policy lock.
+// Returns a function that releases the lock again.
+func (c *minioContext) lockPolicies() func() {
+	c.policyMutex.Lock()
+	return c.policyMutex.Unlock
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -106,10 +177,24 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	// Admin API calls not covered by the admin client must use the same
+	// transport, so they behave the same.
+	transport := madmin.DefaultTransport(ssl)
+	admin.SetCustomTransport(transport)
+
+	scheme := "http"
+	if ssl {
+		scheme = "https"
+	}
 
 	mctx := &minioContext{
-		api:   api,
-		admin: admin,
+		api:         api,
+		admin:       admin,
+		endpointURL: scheme + "://" + endpoint,
+		accessKey:   accessKey,
+		secretKey:   secretKey,
+		httpClient:  &http.Client{Transport: transport},
+		strict:      d.Get(keyConfigStrict).(bool),
 	}
 
 	return mctx, diags
@@ -123,7 +208,69 @@ func interfaceToStringSlice(rawList []interface{}) []string {
 	return list
 }
 
+// Remove empty entries from a string slice.
+func stringSliceFilterEmpty(slice []string) []string {
+	var filtered []string
+	for _, value := range slice {
+		if value != "" {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
+
+// Split a comma separated list of policy names, as returned by the minio API.
+// Empty entries are dropped.
+func splitPolicyNames(policies string) []string {
+	return stringSliceFilterEmpty(strings.Split(policies, ","))
+}
+
+// Clean up a string list in a raw state during state upgrades, by dropping
+// empty entries and duplicates.
+// Used when a TypeList attribute is changed to a TypeSet, since both are
+// represented as a list in the raw state.
+func stateUpgradeStringListToSet(rawState map[string]interface{}, key string) {
+	rawList, ok := rawState[key].([]interface{})
+	if !ok {
+		return
+	}
+	var cleaned []interface{}
+	var seen []string
+	for _, rawValue := range rawList {
+		value, ok := rawValue.(string)
+		if !ok || value == "" || stringSliceContains(seen, value) {
+			continue
+		}
+		seen = append(seen, value)
+		cleaned = append(cleaned, value)
+	}
+	rawState[key] = cleaned
+}
+
 func dataGetStringList(data *schema.ResourceData, key string) []string {
 	rawList := data.Get(key).([]interface{})
 	return interfaceToStringSlice(rawList)
 }
+
+func dataGetStringSet(data *schema.ResourceData, key string) []string {
+	rawSet := data.Get(key).(*schema.Set)
+	return interfaceToStringSlice(rawSet.List())
+}
+
+func dataGetStringMap(data *schema.ResourceData, key string) map[string]string {
+	values := make(map[string]string)
+	for k, v := range data.Get(key).(map[string]interface{}) {
+		values[k] = v.(string)
+	}
+	return values
+}
+
+// Set multiple attributes at once, stopping at the first error.
+func dataSetValues(data *schema.ResourceData, values map[string]interface{}) error {
+	for key, value := range values {
+		if err := data.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
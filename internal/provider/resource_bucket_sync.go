@@ -0,0 +1,527 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyBucketSyncSourceDir            = "source_dir"
+	keyBucketSyncBucket               = "bucket"
+	keyBucketSyncPrefix               = "prefix"
+	keyBucketSyncInclude              = "include"
+	keyBucketSyncExclude              = "exclude"
+	keyBucketSyncContentTypeDetection = "content_type_detection"
+	keyBucketSyncDeleteExtraneous     = "delete_extraneous"
+	keyBucketSyncParallelism          = "parallelism"
+	keyBucketSyncSSE                  = "sse"
+	keyBucketSyncFiles                = "files"
+	keyBucketSyncExtraneous           = "extraneous_objects"
+)
+
+const (
+	contentTypeDetectionExtension = "extension"
+	contentTypeDetectionContent   = "content"
+	contentTypeDetectionNone      = "none"
+)
+
+// User metadata key under which the content hash of uploaded files is stored.
+const bucketSyncHashMetadata = "Content-Sha256"
+
+func schemaBucketSync() objectSchema {
+	return map[string]*schema.Schema{
+		keyBucketSyncSourceDir: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The local directory to upload.",
+		},
+		keyBucketSyncBucket: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the bucket.",
+			ForceNew:    true,
+		},
+		keyBucketSyncPrefix: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Prefix for the object keys. Usually ends with a `/`.",
+			ForceNew:    true,
+		},
+		keyBucketSyncInclude: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "Only upload files matching one of these globs.\nGlobs without a `/` are matched against the file name, others against the path relative to `source_dir`.\nAll files are uploaded if empty.",
+		},
+		keyBucketSyncExclude: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "Skip files matching one of these globs. Takes precedence over `include`.",
+		},
+		keyBucketSyncContentTypeDetection: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      contentTypeDetectionExtension,
+			Description:  "How the content type of objects is determined: `extension` uses the file extension, `content` sniffs the file content and `none` always uses `application/octet-stream`.",
+			ValidateFunc: validation.StringInSlice([]string{contentTypeDetectionExtension, contentTypeDetectionContent, contentTypeDetectionNone}, false),
+		},
+		keyBucketSyncDeleteExtraneous: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, all objects below `prefix` that do not correspond to a local file are deleted, see `extraneous_objects`.\nOtherwise only objects previously uploaded by this resource are deleted.",
+		},
+		keyBucketSyncParallelism: &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      4,
+			Description:  "The number of concurrent uploads.",
+			ValidateFunc: validation.IntBetween(1, 64),
+		},
+		keyBucketSyncSSE: schemaSSE("Server side encryption of the uploaded objects.\nChanging it uploads all files again.", false),
+		keyBucketSyncFiles: &schema.Schema{
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Computed:    true,
+			Description: "The SHA-256 hashes of the uploaded files, keyed by the path relative to `source_dir`.",
+		},
+		keyBucketSyncExtraneous: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Computed:    true,
+			Description: "The keys below `prefix`, without the prefix, of objects that were not uploaded by this resource.\nThey are deleted on the next apply if `delete_extraneous` is true, and are never deleted when the resource is destroyed.",
+		},
+	}
+}
+
+func resourceBucketSync() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Uploads the content of a local directory into a bucket.",
+		CreateContext: resourceBucketSyncCreate,
+		ReadContext:   resourceBucketSyncRead,
+		UpdateContext: resourceBucketSyncUpdate,
+		DeleteContext: resourceBucketSyncDelete,
+		CustomizeDiff: resourceBucketSyncCustomizeDiff,
+		Schema:        schemaBucketSync(),
+	}
+}
+
+// Check if a path relative to the source directory matches one of the globs.
+func bucketSyncMatches(globs []string, relPath string) bool {
+	for _, glob := range globs {
+		name := relPath
+		if !strings.Contains(glob, "/") {
+			name = path.Base(relPath)
+		}
+		if matched, _ := path.Match(glob, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Hash a file with SHA-256.
+func bucketSyncHashFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Build the manifest of a source directory.
+// Returns the content hashes of all files, keyed by their slash separated
+// path relative to the directory.
+func bucketSyncManifest(sourceDir string, include []string, exclude []string) (map[string]string, error) {
+	manifest := make(map[string]string)
+	err := filepath.Walk(sourceDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if len(include) > 0 && !bucketSyncMatches(include, relPath) {
+			return nil
+		}
+		if bucketSyncMatches(exclude, relPath) {
+			return nil
+		}
+		hash, err := bucketSyncHashFile(filePath)
+		if err != nil {
+			return err
+		}
+		manifest[relPath] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not read source directory %s: %s", sourceDir, err)
+	}
+	return manifest, nil
+}
+
+func resourceBucketSyncCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(keyBucketSyncSourceDir) || !d.NewValueKnown(keyBucketSyncInclude) || !d.NewValueKnown(keyBucketSyncExclude) {
+		return d.SetNewComputed(keyBucketSyncFiles)
+	}
+
+	manifest, err := bucketSyncManifest(
+		d.Get(keyBucketSyncSourceDir).(string),
+		interfaceToStringSlice(d.Get(keyBucketSyncInclude).([]interface{})),
+		interfaceToStringSlice(d.Get(keyBucketSyncExclude).([]interface{})),
+	)
+	if err != nil {
+		return err
+	}
+
+	old := d.Get(keyBucketSyncFiles).(map[string]interface{})
+	changed := len(old) != len(manifest)
+	for relPath, hash := range manifest {
+		if old[relPath] != hash {
+			changed = true
+		}
+	}
+	if changed {
+		if err := d.SetNew(keyBucketSyncFiles, manifest); err != nil {
+			return err
+		}
+	}
+
+	// Objects that appeared outside of terraform are deleted by an update.
+	if d.Get(keyBucketSyncDeleteExtraneous).(bool) && len(d.Get(keyBucketSyncExtraneous).([]interface{})) > 0 {
+		return d.SetNew(keyBucketSyncExtraneous, []string{})
+	}
+	return nil
+}
+
+// Run a function for all keys, with at most parallelism calls at a time.
+// Returns an error listing all failed keys.
+func bucketSyncRun(parallelism int, keys []string, fn func(key string) error) error {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var failures []string
+	semaphore := make(chan struct{}, parallelism)
+
+	for _, key := range keys {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := fn(key); err != nil {
+				mutex.Lock()
+				failures = append(failures, fmt.Sprintf("%s: %s", key, err))
+				mutex.Unlock()
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("%d of %d operations failed:\n%s", len(failures), len(keys), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// Determine the content type of a file.
+func bucketSyncContentType(mode string, file *os.File) (string, error) {
+	switch mode {
+	case contentTypeDetectionExtension:
+		if contentType := mime.TypeByExtension(path.Ext(file.Name())); contentType != "" {
+			return contentType, nil
+		}
+	case contentTypeDetectionContent:
+		head := make([]byte, 512)
+		n, err := file.Read(head)
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		return http.DetectContentType(head[:n]), nil
+	}
+	return "application/octet-stream", nil
+}
+
+// Upload a single file.
+// Runs concurrently, so it must not access the resource data.
+func bucketSyncUpload(ctx context.Context, client *minio.Client, bucket string, objectKey string, filePath string, contentTypeDetection string, hash string, sse encrypt.ServerSide) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	contentType, err := bucketSyncContentType(contentTypeDetection, file)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Uploading %s to %s/%s\n", filePath, bucket, objectKey)
+	_, err = client.PutObject(ctx, bucket, objectKey, file, info.Size(), minio.PutObjectOptions{
+		ContentType:          contentType,
+		UserMetadata:         map[string]string{bucketSyncHashMetadata: hash},
+		ServerSideEncryption: sse,
+	})
+	return err
+}
+
+// Upload all changed files and remove all deleted files, as well as all
+// extraneous objects if enabled.
+// old is the manifest of the objects in the bucket, or nil to upload everything.
+func bucketSyncApply(ctx context.Context, d *schema.ResourceData, m interface{}, old map[string]interface{}) diag.Diagnostics {
+	// The resource data is not safe for concurrent use, so all values are
+	// read before any work is started.
+	client := m.(*minioContext).api
+	bucket := d.Get(keyBucketSyncBucket).(string)
+	prefix := d.Get(keyBucketSyncPrefix).(string)
+	sourceDir := d.Get(keyBucketSyncSourceDir).(string)
+	contentTypeDetection := d.Get(keyBucketSyncContentTypeDetection).(string)
+	deleteExtraneous := d.Get(keyBucketSyncDeleteExtraneous).(bool)
+	parallelism := d.Get(keyBucketSyncParallelism).(int)
+
+	manifest, err := bucketSyncManifest(
+		sourceDir,
+		interfaceToStringSlice(d.Get(keyBucketSyncInclude).([]interface{})),
+		interfaceToStringSlice(d.Get(keyBucketSyncExclude).([]interface{})),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sse, err := dataGetSSE(d, keyBucketSyncSSE)
+	if err != nil {
+		return []diag.Diagnostic{diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: cty.GetAttrPath(keyBucketSyncSSE),
+		}}
+	}
+
+	var uploads, removals []string
+	for relPath, hash := range manifest {
+		if old[relPath] != hash {
+			uploads = append(uploads, relPath)
+		}
+	}
+	for relPath := range old {
+		if _, ok := manifest[relPath]; !ok {
+			removals = append(removals, relPath)
+		}
+	}
+	if deleteExtraneous {
+		remote, err := bucketSyncListRemote(ctx, client, bucket, prefix)
+		if err != nil {
+			return diag.Errorf("Could not list objects in bucket %s: %s", bucket, err)
+		}
+		for relPath := range remote {
+			_, local := manifest[relPath]
+			_, uploaded := old[relPath]
+			if !local && !uploaded {
+				removals = append(removals, relPath)
+			}
+		}
+	}
+
+	err = bucketSyncRun(parallelism, uploads, func(relPath string) error {
+		filePath := filepath.Join(sourceDir, filepath.FromSlash(relPath))
+		return bucketSyncUpload(ctx, client, bucket, prefix+relPath, filePath, contentTypeDetection, manifest[relPath], sse)
+	})
+	if err != nil {
+		return diag.Errorf("Could not upload files: %s", err)
+	}
+
+	err = bucketSyncRun(parallelism, removals, func(relPath string) error {
+		log.Printf("[DEBUG] Removing %s/%s\n", bucket, prefix+relPath)
+		return client.RemoveObject(ctx, bucket, prefix+relPath, minio.RemoveObjectOptions{})
+	})
+	if err != nil {
+		return diag.Errorf("Could not remove objects: %s", err)
+	}
+
+	if err := d.Set(keyBucketSyncFiles, manifest); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceBucketSyncCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	bucket := d.Get(keyBucketSyncBucket).(string)
+	prefix := d.Get(keyBucketSyncPrefix).(string)
+
+	if diags := bucketSyncApply(ctx, d, m, nil); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(bucket + "/" + prefix)
+	return resourceBucketSyncRead(ctx, d, m)
+}
+
+// List the content hashes of all objects below the prefix, keyed by the
+// object key without the prefix.
+// Objects not uploaded by this resource have an empty hash.
+func bucketSyncListRemote(ctx context.Context, client *minio.Client, bucket string, prefix string) (map[string]string, error) {
+	remote := make(map[string]string)
+	objects := client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithMetadata: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		hash := ""
+		for key, value := range object.UserMetadata {
+			if strings.EqualFold(strings.TrimPrefix(strings.ToLower(key), "x-amz-meta-"), bucketSyncHashMetadata) {
+				hash = value
+			}
+		}
+		remote[strings.TrimPrefix(object.Key, prefix)] = hash
+	}
+	return remote, nil
+}
+
+func resourceBucketSyncRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyBucketSyncBucket).(string)
+	prefix := d.Get(keyBucketSyncPrefix).(string)
+
+	remote, err := bucketSyncListRemote(ctx, client, bucket, prefix)
+	if err != nil {
+		return diag.Errorf("Could not list objects in bucket %s: %s", bucket, err)
+	}
+
+	// Objects that were deleted or changed outside of terraform show up as
+	// changes in the next plan.
+	files := make(map[string]interface{})
+	for relPath, hash := range d.Get(keyBucketSyncFiles).(map[string]interface{}) {
+		remoteHash, ok := remote[relPath]
+		if !ok {
+			continue
+		}
+		if remoteHash == "" {
+			// The server did not return metadata, so changes can not be detected.
+			remoteHash = hash.(string)
+		}
+		files[relPath] = remoteHash
+	}
+
+	// Objects that were not uploaded by this resource are tracked separately,
+	// so they are never deleted on destroy.
+	extraneous := []string{}
+	for relPath := range remote {
+		if _, ok := files[relPath]; !ok {
+			extraneous = append(extraneous, relPath)
+		}
+	}
+	sort.Strings(extraneous)
+
+	if err := dataSetValues(d, map[string]interface{}{
+		keyBucketSyncFiles:      files,
+		keyBucketSyncExtraneous: extraneous,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceBucketSyncUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	oldRaw, _ := d.GetChange(keyBucketSyncFiles)
+	old := oldRaw.(map[string]interface{})
+
+	// Changing the detection mode or the encryption requires uploading
+	// everything again.
+	if d.HasChange(keyBucketSyncContentTypeDetection) || d.HasChange(keyBucketSyncSSE) {
+		reupload := make(map[string]interface{})
+		for relPath := range old {
+			reupload[relPath] = ""
+		}
+		old = reupload
+	}
+
+	if diags := bucketSyncApply(ctx, d, m, old); diags.HasError() {
+		return diags
+	}
+
+	return resourceBucketSyncRead(ctx, d, m)
+}
+
+func resourceBucketSyncDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyBucketSyncBucket).(string)
+	prefix := d.Get(keyBucketSyncPrefix).(string)
+	parallelism := d.Get(keyBucketSyncParallelism).(int)
+
+	var relPaths []string
+	for relPath := range d.Get(keyBucketSyncFiles).(map[string]interface{}) {
+		relPaths = append(relPaths, relPath)
+	}
+
+	err := bucketSyncRun(parallelism, relPaths, func(relPath string) error {
+		return client.RemoveObject(ctx, bucket, prefix+relPath, minio.RemoveObjectOptions{})
+	})
+	if err != nil {
+		return diag.Errorf("Could not remove objects: %s", err)
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 
@@ -19,6 +20,9 @@ import (
 const (
 	keyGroupName     = "name"
 	keyGroupPolicies = "policies"
+	keyGroupMembers  = "members"
+	keyGroupEnabled  = "enabled"
+	keyGroupStrict   = "strict"
 )
 
 func schemaGroup() objectSchema {
@@ -30,18 +34,41 @@ func schemaGroup() objectSchema {
 			ForceNew:    true,
 		},
 		keyGroupPolicies: &schema.Schema{
-			Type: schema.TypeList,
+			Type: schema.TypeSet,
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
 			Optional:    true,
-			Description: "The policies assigned to this group.",
+			Computed:    true,
+			Description: "The policies assigned to this group.\nIf not specified, the policies are not managed by this resource, e.g. because they are attached with `minio_group_policy_attachment`. An empty list is treated the same way.\nIf specified, this is the full list of policies and all other policies are detached, so it must not be combined with `minio_group_policy_attachment` for the same group.",
+		},
+		keyGroupMembers: &schema.Schema{
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Computed:    true,
+			Description: "The access keys of the users that are members of this group.\nIf not specified, the members are not managed by this resource. An empty list is treated the same way, so it does not remove existing members; use `minio_group_membership` with an empty `users` list to remove all members.\nShould not be combined with `minio_group_membership` or the `groups` attribute of `minio_user` for the same group.",
+		},
+		keyGroupEnabled: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "If false, the group is disabled and its policies no longer apply to its members.",
+		},
+		keyGroupStrict: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, the group is removed again if assigning policies fails during creation, and an error is returned instead of a warning.\nThe provider level `strict` setting enables this for all groups.",
 		},
 	}
 }
 
 func resourceGroup() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages a group.\nDeleting the group first removes all its members, since only empty groups can be removed. This includes members managed by `minio_group_membership` or the `groups` attribute of `minio_user`.",
 		CreateContext: resourceGroupCreate,
 		ReadContext:   resourceGroupRead,
 		UpdateContext: resourceGroupUpdate,
@@ -49,60 +76,209 @@ func resourceGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: schemaGroup(),
+		Schema:        schemaGroup(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceGroupV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceGroupStateUpgradeV0,
+			},
+		},
+	}
+}
+
+// Version 0 of the schema used a list for policies.
+// This is a frozen copy, it must not change with the current schema.
+func resourceGroupV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name for the group.",
+				ForceNew:    true,
+			},
+			"policies": &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The policies assigned to this group.",
+			},
+			"members": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Computed:    true,
+				Description: "The access keys of the users that are members of this group.\nIf not specified, the members are not managed by this resource.\nShould not be combined with `minio_group_membership` or the `groups` attribute of `minio_user` for the same group.",
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If false, the group is disabled and its policies no longer apply to its members.",
+			},
+		},
 	}
 }
 
+func resourceGroupStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	stateUpgradeStringListToSet(rawState, keyGroupPolicies)
+	return rawState, nil
+}
+
 func datasourceGroup() *schema.Resource {
+	s := schemaGroup()
+	s[keyGroupEnabled].Optional = false
+	s[keyGroupEnabled].Default = nil
+	s[keyGroupEnabled].Computed = true
+	delete(s, keyGroupStrict)
 	return &schema.Resource{
 		ReadContext: resourceGroupRead,
-		Schema: schemaGroup(),
+		Schema: s,
 	}
 }
 
 func dataGetGroupPolicies(data *schema.ResourceData) []string {
-	policies := data.Get(keyGroupPolicies).([]interface{})
-	var policyStrings []string
-	for _, policyRaw := range policies {
-		policyStrings = append(policyStrings, policyRaw.(string))
+	return dataGetStringSet(data, keyGroupPolicies)
+}
+
+func dataGetGroupStatus(data *schema.ResourceData) madmin.GroupStatus {
+	if data.Get(keyGroupEnabled).(bool) {
+		return madmin.GroupEnabled
+	}
+	return madmin.GroupDisabled
+}
+
+// Add and remove users to/from a group, so that the members change from the
+// old to the new set.
+func updateGroupMembers(ctx context.Context, client *madmin.AdminClient, groupName string, old []string, _new []string) error {
+	added, removed := stringSliceDiff(old, _new)
+
+	// NOTE: the member lists must not be empty, because removing an empty
+	// member list removes the whole group.
+	if len(added) > 0 {
+		log.Printf("[DEBUG] Adding users to minio group '%s': %s\n", groupName, strings.Join(added, ", "))
+		err := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+			Group:    groupName,
+			Members:  added,
+			IsRemove: false,
+		})
+		if err != nil {
+			return fmt.Errorf("Could not add users to group %s: %s", groupName, err)
+		}
+	}
+	if len(removed) > 0 {
+		log.Printf("[DEBUG] Removing users from minio group '%s': %s\n", groupName, strings.Join(removed, ", "))
+		err := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+			Group:    groupName,
+			Members:  removed,
+			IsRemove: true,
+		})
+		if err != nil {
+			return fmt.Errorf("Could not remove users from group %s: %s", groupName, err)
+		}
 	}
-	return policyStrings
+	return nil
+}
+
+// Remove a group, including all its members.
+func removeGroup(ctx context.Context, client *madmin.AdminClient, groupName string) error {
+	// Only empty groups can be removed.
+	info, err := client.GetGroupDescription(ctx, groupName)
+	if err != nil {
+		return fmt.Errorf("Could not load group %s: %s", groupName, err)
+	}
+	if err := updateGroupMembers(ctx, client, groupName, info.Members, nil); err != nil {
+		return err
+	}
+
+	return client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+		Group:    groupName,
+		IsRemove: true,
+	})
+}
+
+// Remove a partially created group again, after a step of the creation failed.
+// Returns the given diagnostics, extended with errors of the rollback.
+func groupCreateRollback(ctx context.Context, client *madmin.AdminClient, groupName string, diags diag.Diagnostics) diag.Diagnostics {
+	log.Printf("[DEBUG] Rolling back creation of minio group '%s'\n", groupName)
+	if err := removeGroup(ctx, client, groupName); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Could not roll back creation of group " + groupName + ": " + err.Error(),
+		})
+	}
+	return diags
 }
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	client := m.(*minioContext).admin
 	groupName := d.Get(keyGroupName).(string)
 	policies := dataGetGroupPolicies(d)
+	members := dataGetStringSet(d, keyGroupMembers)
+	strict := m.(*minioContext).strict || d.Get(keyGroupStrict).(bool)
 
 	log.Printf("[DEBUG] Creating minio group: '%s'\n", groupName)
 	err := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
 		Group:    groupName,
+		Members:  members,
 		IsRemove: false,
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// The group exists from here on. In strict mode, any failure removes it
+	// again.
+	fail := func(failure diag.Diagnostics) diag.Diagnostics {
+		if !strict {
+			return failure
+		}
+		return groupCreateRollback(ctx, client, groupName, failure)
+	}
+
+	if status := dataGetGroupStatus(d); status != madmin.GroupEnabled {
+		if err := client.SetGroupStatus(ctx, groupName, status); err != nil {
+			return fail(diag.FromErr(err))
+		}
+	}
+
 	if len(policies) > 0 {
 		policyString := strings.Join(policies, ",")
 
 		if err := client.SetPolicy(ctx, policyString, groupName, true); err != nil {
-			// TODO: the group is already created at this point, so we just add a
-			// warning if the policy could not be applied.
-			// Maybe we should hard-error instead and delete the group?
+			if strict {
+				return fail([]diag.Diagnostic{diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Could not set policy for group: " + err.Error(),
+					AttributePath: cty.GetAttrPath(keyGroupPolicies),
+				}})
+			}
+			// Without strict mode, the group is kept and only a warning is
+			// added if the policy could not be applied.
 			diags = append(diags, diag.Diagnostic{
 				Severity:      diag.Warning,
 				Summary:       "Could not set policy for user: " + err.Error(),
 				AttributePath: cty.GetAttrPath(keyGroupPolicies),
 			})
 			if err := d.Set(keyGroupPolicies, nil); err != nil {
-				return diag.FromErr(err)
+				return fail(diag.FromErr(err))
 			}
 		}
 	}
+	if err := d.Set(keyGroupMembers, members); err != nil {
+		return fail(diag.FromErr(err))
+	}
 
 	d.SetId(groupName)
 	return diags
@@ -128,14 +304,13 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	// Policies.
-	var policies []interface{}
-	for _, part := range strings.Split(info.Policy, ",") {
-		if part != "" {
-			policies = append(policies, &part)
-		}
+	if err := d.Set(keyGroupPolicies, splitPolicyNames(info.Policy)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyGroupMembers, stringSliceFilterEmpty(info.Members)); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set(keyGroupPolicies, policies); err != nil {
+	if err := d.Set(keyGroupEnabled, info.Status == string(madmin.GroupEnabled)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -143,26 +318,59 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
 	if d.HasChange(keyGroupName) {
 		return diag.FromErr(errors.New("Groups can not be renamed"))
 	}
 
 	groupName := d.Id()
-	client := m.(*minioContext).admin
 
 	if d.HasChange(keyGroupPolicies) {
+		mctx := m.(*minioContext)
+		unlock := mctx.lockPolicies()
+		defer unlock()
+
+		// Compute the changes based on the actual server state, so policies
+		// attached outside of this resource are detached as well. The
+		// attribute is the full list of policies, which is why it must not be
+		// combined with policy attachment resources.
+		oldPolicies, err := groupGetPolicies(ctx, m, groupName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		newPolicies := dataGetGroupPolicies(d)
-		// FIXME: handle removing old policies!
-		if len(newPolicies) == 0 {
+		if err := mctx.updateEntityPolicies(ctx, groupName, true, oldPolicies, newPolicies); err != nil {
 			return []diag.Diagnostic{diag.Diagnostic{
 				Severity:      diag.Error,
-				Summary:       "Can not set policies to empty after the group has been assigned other policies. This is a minio API limitation.",
+				Summary:       "Could not change group policies: " + err.Error(),
 				AttributePath: cty.GetAttrPath(keyGroupPolicies),
 			}}
 		}
-		policiesJoined := strings.Join(newPolicies, ",")
-		if err := client.SetPolicy(ctx, policiesJoined, groupName, true); err != nil {
-			return diag.Errorf("Could not change group policies: %s", err)
+	}
+
+	if d.HasChange(keyGroupMembers) {
+		oldRaw, newRaw := d.GetChange(keyGroupMembers)
+		old := interfaceToStringSlice(oldRaw.(*schema.Set).List())
+		_new := interfaceToStringSlice(newRaw.(*schema.Set).List())
+		client := m.(*minioContext).admin
+		if err := updateGroupMembers(ctx, client, groupName, old, _new); err != nil {
+			return []diag.Diagnostic{diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: cty.GetAttrPath(keyGroupMembers),
+			}}
+		}
+	}
+
+	if d.HasChange(keyGroupEnabled) {
+		client := m.(*minioContext).admin
+		if err := client.SetGroupStatus(ctx, groupName, dataGetGroupStatus(d)); err != nil {
+			return []diag.Diagnostic{diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Could not change group status: " + err.Error(),
+				AttributePath: cty.GetAttrPath(keyGroupEnabled),
+			}}
 		}
 	}
 
@@ -170,16 +378,16 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	groupName := d.Id()
 	client := m.(*minioContext).admin
-	err := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
-		Group:    groupName,
-		IsRemove: true,
-	})
-	if err != nil {
+	// This also strips all members from the group, regardless of how they
+	// were added.
+	if err := removeGroup(ctx, client, groupName); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyGroupMembershipGroup = "group_name"
+	keyGroupMembershipUsers = "users"
+)
+
+func schemaGroupMembership() objectSchema {
+	return map[string]*schema.Schema{
+		keyGroupMembershipGroup: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the group. This is also the unique ID.",
+			ForceNew:    true,
+		},
+		keyGroupMembershipUsers: &schema.Schema{
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Required:    true,
+			Description: "The access keys of the users that are members of the group. Users not in this list are removed from the group.",
+		},
+	}
+}
+
+func resourceGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the full list of members of a group.\nShould not be combined with the `members` attribute of `minio_group` or the `groups` attribute of `minio_user` for the same group.",
+		CreateContext: resourceGroupMembershipCreate,
+		ReadContext:   resourceGroupMembershipRead,
+		UpdateContext: resourceGroupMembershipUpdate,
+		DeleteContext: resourceGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: schemaGroupMembership(),
+	}
+}
+
+func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	client := m.(*minioContext).admin
+	groupName := d.Get(keyGroupMembershipGroup).(string)
+	users := dataGetStringSet(d, keyGroupMembershipUsers)
+
+	info, err := client.GetGroupDescription(ctx, groupName)
+	if err != nil {
+		return diag.Errorf("Could not load group %s: %s", groupName, err)
+	}
+	if err := updateGroupMembers(ctx, client, groupName, info.Members, users); err != nil {
+		return []diag.Diagnostic{diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: cty.GetAttrPath(keyGroupMembershipUsers),
+		}}
+	}
+
+	d.SetId(groupName)
+	return resourceGroupMembershipRead(ctx, d, m)
+}
+
+func resourceGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	groupName := d.Id()
+	client := m.(*minioContext).admin
+
+	info, err := client.GetGroupDescription(ctx, groupName)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchGroup" {
+			log.Printf("[WARN] Minio group '%s' no longer exists\n", groupName)
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("Could not load group %s: %s", groupName, err)
+	}
+
+	if err := d.Set(keyGroupMembershipGroup, groupName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyGroupMembershipUsers, stringSliceFilterEmpty(info.Members)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	groupName := d.Id()
+	client := m.(*minioContext).admin
+
+	if d.HasChange(keyGroupMembershipUsers) {
+		// Like on creation, the delta is computed from the current members,
+		// since the state may be outdated.
+		info, err := client.GetGroupDescription(ctx, groupName)
+		if err != nil {
+			return diag.Errorf("Could not load group %s: %s", groupName, err)
+		}
+		users := dataGetStringSet(d, keyGroupMembershipUsers)
+		if err := updateGroupMembers(ctx, client, groupName, info.Members, users); err != nil {
+			return []diag.Diagnostic{diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: cty.GetAttrPath(keyGroupMembershipUsers),
+			}}
+		}
+	}
+
+	return resourceGroupMembershipRead(ctx, d, m)
+}
+
+func resourceGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	groupName := d.Id()
+	client := m.(*minioContext).admin
+	users := dataGetStringSet(d, keyGroupMembershipUsers)
+
+	if err := updateGroupMembers(ctx, client, groupName, users, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
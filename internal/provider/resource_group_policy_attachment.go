@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyGroupPolicyAttachmentGroup  = "group_name"
+	keyGroupPolicyAttachmentPolicy = "policy_name"
+)
+
+func schemaGroupPolicyAttachment() objectSchema {
+	return map[string]*schema.Schema{
+		keyGroupPolicyAttachmentGroup: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the group.",
+			ForceNew:    true,
+		},
+		keyGroupPolicyAttachmentPolicy: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the canned policy to attach to the group.",
+			ForceNew:    true,
+		},
+	}
+}
+
+func resourceGroupPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Attaches a single canned policy to a group, leaving other policies of the group untouched.\nMust not be combined with the `policies` attribute of a `minio_group` resource for the same group, since that attribute manages the full list of policies and detaches the attached policy again. Omit the attribute instead.",
+		CreateContext: resourceGroupPolicyAttachmentCreate,
+		ReadContext:   resourceGroupPolicyAttachmentRead,
+		DeleteContext: resourceGroupPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePolicyAttachmentImport(keyGroupPolicyAttachmentGroup, keyGroupPolicyAttachmentPolicy),
+		},
+		Schema: schemaGroupPolicyAttachment(),
+	}
+}
+
+// Load the policies currently assigned to a group.
+func groupGetPolicies(ctx context.Context, m interface{}, groupName string) ([]string, error) {
+	client := m.(*minioContext).admin
+	info, err := client.GetGroupDescription(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load group %s: %s", groupName, err)
+	}
+	return splitPolicyNames(info.Policy), nil
+}
+
+func resourceGroupPolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	mctx := m.(*minioContext)
+	groupName := d.Get(keyGroupPolicyAttachmentGroup).(string)
+	policyName := d.Get(keyGroupPolicyAttachmentPolicy).(string)
+
+	// Multiple attachments for the same group may be applied concurrently, so
+	// the read-modify-write cycle must be serialized.
+	unlock := mctx.lockPolicies()
+	defer unlock()
+
+	policies, err := groupGetPolicies(ctx, m, groupName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !stringSliceContains(policies, policyName) {
+		log.Printf("[DEBUG] Attaching policy '%s' to minio group '%s'\n", policyName, groupName)
+		if err := mctx.updateEntityPolicies(ctx, groupName, true, policies, append(policies, policyName)); err != nil {
+			return diag.Errorf("Could not attach policy %s to group %s: %s", policyName, groupName, err)
+		}
+	}
+
+	d.SetId(policyAttachmentID(groupName, policyName))
+	return diags
+}
+
+func resourceGroupPolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	groupName := d.Get(keyGroupPolicyAttachmentGroup).(string)
+	policyName := d.Get(keyGroupPolicyAttachmentPolicy).(string)
+
+	info, err := client.GetGroupDescription(ctx, groupName)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchGroup" {
+			log.Printf("[WARN] Minio group '%s' no longer exists\n", groupName)
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("Could not load group %s: %s", groupName, err)
+	}
+
+	if !stringSliceContains(splitPolicyNames(info.Policy), policyName) {
+		log.Printf("[WARN] Policy '%s' is no longer attached to minio group '%s'\n", policyName, groupName)
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func resourceGroupPolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	mctx := m.(*minioContext)
+	groupName := d.Get(keyGroupPolicyAttachmentGroup).(string)
+	policyName := d.Get(keyGroupPolicyAttachmentPolicy).(string)
+
+	unlock := mctx.lockPolicies()
+	defer unlock()
+
+	policies, err := groupGetPolicies(ctx, m, groupName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if stringSliceContains(policies, policyName) {
+		log.Printf("[DEBUG] Detaching policy '%s' from minio group '%s'\n", policyName, groupName)
+		if err := mctx.updateEntityPolicies(ctx, groupName, true, policies, stringSliceRemove(policies, policyName)); err != nil {
+			return diag.Errorf("Could not detach policy %s from group %s: %s", policyName, groupName, err)
+		}
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyILMTierName            = "name"
+	keyILMTierType            = "type"
+	keyILMTierEndpoint        = "endpoint"
+	keyILMTierBucket          = "bucket"
+	keyILMTierPrefix          = "prefix"
+	keyILMTierRegion          = "region"
+	keyILMTierStorageClass    = "storage_class"
+	keyILMTierAccessKey       = "access_key"
+	keyILMTierSecretKey       = "secret_key"
+	keyILMTierAccountName     = "account_name"
+	keyILMTierAccountKey      = "account_key"
+	keyILMTierCredentialsJSON = "credentials_json"
+)
+
+const (
+	ilmTierTypeS3    = "s3"
+	ilmTierTypeMinIO = "minio"
+	ilmTierTypeAzure = "azure"
+	ilmTierTypeGCS   = "gcs"
+)
+
+func schemaILMTier() objectSchema {
+	return map[string]*schema.Schema{
+		keyILMTierName: &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The name of the tier, in upper case. This is also the unique ID.",
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z0-9_-]+$`), "must only contain upper case letters, digits, - and _"),
+		},
+		keyILMTierType: &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The type of the remote storage: `s3`, `minio`, `azure` or `gcs`.",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{ilmTierTypeS3, ilmTierTypeMinIO, ilmTierTypeAzure, ilmTierTypeGCS}, false),
+		},
+		keyILMTierEndpoint: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The endpoint of the remote storage, including the scheme.\nRequired for `minio`. Not supported for `gcs`.",
+			ForceNew:    true,
+		},
+		keyILMTierBucket: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The remote bucket or container.",
+			ForceNew:    true,
+		},
+		keyILMTierPrefix: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The prefix for transitioned objects in the remote bucket.",
+			ForceNew:    true,
+		},
+		keyILMTierRegion: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The region of the remote storage.",
+			ForceNew:    true,
+		},
+		keyILMTierStorageClass: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The storage class of transitioned objects. Not supported for `minio`.",
+			ForceNew:    true,
+		},
+		keyILMTierAccessKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The access key. Required for `s3` and `minio`.",
+		},
+		keyILMTierSecretKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The secret key. Required for `s3` and `minio`.",
+		},
+		keyILMTierAccountName: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The storage account name. Required for `azure`.",
+			ForceNew:    true,
+		},
+		keyILMTierAccountKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The storage account key. Required for `azure`.",
+		},
+		keyILMTierCredentialsJSON: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The content of the service account credentials file. Required for `gcs`.",
+		},
+	}
+}
+
+func resourceILMTier() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a remote tier, which lifecycle rules can transition objects to.\nCredentials are updated in place, all other changes recreate the tier.",
+		CreateContext: resourceILMTierCreate,
+		ReadContext:   resourceILMTierRead,
+		UpdateContext: resourceILMTierUpdate,
+		DeleteContext: resourceILMTierDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: schemaILMTier(),
+	}
+}
+
+// Check that the attributes required by the tier type are set, and that
+// unsupported attributes are not.
+func ilmTierCheckRequired(d *schema.ResourceData) diag.Diagnostics {
+	var required []string
+	switch d.Get(keyILMTierType).(string) {
+	case ilmTierTypeS3:
+		required = []string{keyILMTierAccessKey, keyILMTierSecretKey}
+	case ilmTierTypeMinIO:
+		required = []string{keyILMTierEndpoint, keyILMTierAccessKey, keyILMTierSecretKey}
+	case ilmTierTypeAzure:
+		required = []string{keyILMTierAccountName, keyILMTierAccountKey}
+	case ilmTierTypeGCS:
+		required = []string{keyILMTierCredentialsJSON}
+	}
+
+	var diags diag.Diagnostics
+	for _, key := range required {
+		if d.Get(key).(string) == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("%s is required for tier type %s", key, d.Get(keyILMTierType).(string)),
+				AttributePath: cty.GetAttrPath(key),
+			})
+		}
+	}
+
+	// The endpoint is computed, so it is only checked before the tier is
+	// created. Afterwards it holds the endpoint reported by the server.
+	if d.Id() == "" && d.Get(keyILMTierType).(string) == ilmTierTypeGCS && d.Get(keyILMTierEndpoint).(string) != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s is not supported for tier type %s", keyILMTierEndpoint, ilmTierTypeGCS),
+			AttributePath: cty.GetAttrPath(keyILMTierEndpoint),
+		})
+	}
+	return diags
+}
+
+// Build the tier configuration.
+func ilmTierConfig(d *schema.ResourceData) (*madmin.TierConfig, error) {
+	name := d.Get(keyILMTierName).(string)
+	endpoint := d.Get(keyILMTierEndpoint).(string)
+	bucket := d.Get(keyILMTierBucket).(string)
+	prefix := d.Get(keyILMTierPrefix).(string)
+	region := d.Get(keyILMTierRegion).(string)
+	storageClass := d.Get(keyILMTierStorageClass).(string)
+
+	switch d.Get(keyILMTierType).(string) {
+	case ilmTierTypeS3:
+		options := []madmin.S3Options{madmin.S3Prefix(prefix), madmin.S3Region(region)}
+		if endpoint != "" {
+			options = append(options, madmin.S3Endpoint(endpoint))
+		}
+		if storageClass != "" {
+			options = append(options, madmin.S3StorageClass(storageClass))
+		}
+		return madmin.NewTierS3(name, d.Get(keyILMTierAccessKey).(string), d.Get(keyILMTierSecretKey).(string), bucket, options...)
+	case ilmTierTypeMinIO:
+		return madmin.NewTierMinIO(name, endpoint, d.Get(keyILMTierAccessKey).(string), d.Get(keyILMTierSecretKey).(string), bucket,
+			madmin.MinIOPrefix(prefix), madmin.MinIORegion(region))
+	case ilmTierTypeAzure:
+		options := []madmin.AzureOptions{madmin.AzurePrefix(prefix), madmin.AzureRegion(region)}
+		if endpoint != "" {
+			options = append(options, madmin.AzureEndpoint(endpoint))
+		}
+		if storageClass != "" {
+			options = append(options, madmin.AzureStorageClass(storageClass))
+		}
+		return madmin.NewTierAzure(name, d.Get(keyILMTierAccountName).(string), d.Get(keyILMTierAccountKey).(string), bucket, options...)
+	case ilmTierTypeGCS:
+		options := []madmin.GCSOptions{madmin.GCSPrefix(prefix), madmin.GCSRegion(region)}
+		if storageClass != "" {
+			options = append(options, madmin.GCSStorageClass(storageClass))
+		}
+		return madmin.NewTierGCS(name, []byte(d.Get(keyILMTierCredentialsJSON).(string)), bucket, options...)
+	default:
+		return nil, fmt.Errorf("Unsupported tier type %s", d.Get(keyILMTierType).(string))
+	}
+}
+
+func resourceILMTierCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	name := d.Get(keyILMTierName).(string)
+
+	if diags := ilmTierCheckRequired(d); diags.HasError() {
+		return diags
+	}
+
+	config, err := ilmTierConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.AddTier(ctx, config); err != nil {
+		return diag.Errorf("Could not add tier %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	return resourceILMTierRead(ctx, d, m)
+}
+
+func resourceILMTierRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	name := d.Id()
+
+	tiers, err := client.ListTiers(ctx)
+	if err != nil {
+		return diag.Errorf("Could not list tiers: %s", err)
+	}
+	var config *madmin.TierConfig
+	for _, tier := range tiers {
+		if tier.Name == name {
+			config = tier
+		}
+	}
+	if config == nil {
+		// The tier was removed outside of terraform.
+		d.SetId("")
+		return diags
+	}
+
+	// Secrets are redacted by the server, so they are kept as configured.
+	values := map[string]interface{}{
+		keyILMTierName: name,
+	}
+	switch config.Type {
+	case madmin.S3:
+		values[keyILMTierType] = ilmTierTypeS3
+		values[keyILMTierEndpoint] = config.S3.Endpoint
+		values[keyILMTierBucket] = config.S3.Bucket
+		values[keyILMTierPrefix] = config.S3.Prefix
+		values[keyILMTierRegion] = config.S3.Region
+		values[keyILMTierStorageClass] = config.S3.StorageClass
+		values[keyILMTierAccessKey] = config.S3.AccessKey
+	case madmin.MinIO:
+		values[keyILMTierType] = ilmTierTypeMinIO
+		values[keyILMTierEndpoint] = config.MinIO.Endpoint
+		values[keyILMTierBucket] = config.MinIO.Bucket
+		values[keyILMTierPrefix] = config.MinIO.Prefix
+		values[keyILMTierRegion] = config.MinIO.Region
+		values[keyILMTierAccessKey] = config.MinIO.AccessKey
+	case madmin.Azure:
+		values[keyILMTierType] = ilmTierTypeAzure
+		values[keyILMTierEndpoint] = config.Azure.Endpoint
+		values[keyILMTierBucket] = config.Azure.Bucket
+		values[keyILMTierPrefix] = config.Azure.Prefix
+		values[keyILMTierRegion] = config.Azure.Region
+		values[keyILMTierStorageClass] = config.Azure.StorageClass
+		values[keyILMTierAccountName] = config.Azure.AccountName
+	case madmin.GCS:
+		values[keyILMTierType] = ilmTierTypeGCS
+		values[keyILMTierEndpoint] = config.GCS.Endpoint
+		values[keyILMTierBucket] = config.GCS.Bucket
+		values[keyILMTierPrefix] = config.GCS.Prefix
+		values[keyILMTierRegion] = config.GCS.Region
+		values[keyILMTierStorageClass] = config.GCS.StorageClass
+	default:
+		return diag.Errorf("Tier %s has an unsupported type", name)
+	}
+
+	if err := dataSetValues(d, values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceILMTierUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	name := d.Id()
+
+	if diags := ilmTierCheckRequired(d); diags.HasError() {
+		return diags
+	}
+
+	// Only credentials can change without recreating the tier.
+	var creds madmin.TierCreds
+	switch d.Get(keyILMTierType).(string) {
+	case ilmTierTypeS3, ilmTierTypeMinIO:
+		creds.AccessKey = d.Get(keyILMTierAccessKey).(string)
+		creds.SecretKey = d.Get(keyILMTierSecretKey).(string)
+	case ilmTierTypeAzure:
+		creds.SecretKey = d.Get(keyILMTierAccountKey).(string)
+	case ilmTierTypeGCS:
+		creds.CredsJSON = []byte(d.Get(keyILMTierCredentialsJSON).(string))
+	}
+	if err := client.EditTier(ctx, name, creds); err != nil {
+		return diag.Errorf("Could not update credentials of tier %s: %s", name, err)
+	}
+
+	return resourceILMTierRead(ctx, d, m)
+}
+
+func resourceILMTierDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	name := d.Id()
+
+	if err := client.RemoveTier(ctx, name); err != nil {
+		return diag.Errorf("Could not remove tier %s: %s", name, err)
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyKMSKeyKeyID           = "key_id"
+	keyKMSKeyDeleteOnDestroy = "delete_on_destroy"
+)
+
+func schemaKMSKey() objectSchema {
+	return map[string]*schema.Schema{
+		keyKMSKeyKeyID: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the key. This is also the unique ID.",
+			ForceNew:    true,
+		},
+		keyKMSKeyDeleteOnDestroy: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, the key is deleted from the KMS when the resource is destroyed.\nAll objects encrypted with the key become unreadable. By default the key is only removed from the state.",
+		},
+	}
+}
+
+func resourceKMSKey() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a key of the KMS configured for the server.",
+		CreateContext: resourceKMSKeyCreate,
+		ReadContext:   resourceKMSKeyRead,
+		UpdateContext: resourceKMSKeyUpdate,
+		DeleteContext: resourceKMSKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: schemaKMSKey(),
+	}
+}
+
+// Check that a key can be used to encrypt and decrypt data.
+func kmsKeyVerify(ctx context.Context, client *madmin.AdminClient, keyID string) error {
+	status, err := client.GetKeyStatus(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	if status.EncryptionErr != "" {
+		return fmt.Errorf("Key %s can not encrypt: %s", keyID, status.EncryptionErr)
+	}
+	if status.DecryptionErr != "" {
+		return fmt.Errorf("Key %s can not decrypt: %s", keyID, status.DecryptionErr)
+	}
+	return nil
+}
+
+func resourceKMSKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	keyID := d.Get(keyKMSKeyKeyID).(string)
+
+	if err := client.CreateKey(ctx, keyID); err != nil {
+		return diag.Errorf("Could not create key %s: %s", keyID, err)
+	}
+	d.SetId(keyID)
+
+	if err := kmsKeyVerify(ctx, client, keyID); err != nil {
+		return diag.Errorf("Created key %s failed verification: %s", keyID, err)
+	}
+
+	return resourceKMSKeyRead(ctx, d, m)
+}
+
+func resourceKMSKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	keyID := d.Id()
+
+	if err := kmsKeyVerify(ctx, client, keyID); err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioKMSKeyNotFoundException" {
+			log.Printf("[DEBUG] Key %s does not exist anymore\n", keyID)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(keyKMSKeyKeyID, keyID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceKMSKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only delete_on_destroy can change, which is stored in the state.
+	return resourceKMSKeyRead(ctx, d, m)
+}
+
+func resourceKMSKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	keyID := d.Id()
+
+	if d.Get(keyKMSKeyDeleteOnDestroy).(bool) {
+		if err := client.DeleteKey(ctx, keyID); err != nil {
+			return diag.Errorf("Could not delete key %s: %s", keyID, err)
+		}
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyLDAPConfigServerAddr         = "server_addr"
+	keyLDAPConfigLookupBindDN       = "lookup_bind_dn"
+	keyLDAPConfigLookupBindPassword = "lookup_bind_password"
+	keyLDAPConfigUserDNSearchBaseDN = "user_dn_search_base_dn"
+	keyLDAPConfigUserDNSearchFilter = "user_dn_search_filter"
+	keyLDAPConfigGroupSearchBaseDN  = "group_search_base_dn"
+	keyLDAPConfigGroupSearchFilter  = "group_search_filter"
+	keyLDAPConfigTLSSkipVerify      = "tls_skip_verify"
+	keyLDAPConfigServerInsecure     = "server_insecure"
+	keyLDAPConfigServerStartTLS     = "server_starttls"
+)
+
+func resourceLDAPConfig() *schema.Resource {
+	target := configTarget{
+		subsystem: "identity_ldap",
+		singleton: true,
+		fields: objectSchema{
+			keyLDAPConfigServerAddr: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The address of the LDAP server as `host:port`.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			keyLDAPConfigLookupBindDN: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The DN of the service account used to look up users and groups.",
+			},
+			keyLDAPConfigLookupBindPassword: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the lookup service account.",
+			},
+			keyLDAPConfigUserDNSearchBaseDN: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The base DN to search users in, e.g. `ou=people,dc=example,dc=com`.",
+			},
+			keyLDAPConfigUserDNSearchFilter: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The filter to find a user, with `%s` as placeholder for the username, e.g. `(uid=%s)`.",
+			},
+			keyLDAPConfigGroupSearchBaseDN: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The base DN to search groups in, e.g. `ou=groups,dc=example,dc=com`.",
+			},
+			keyLDAPConfigGroupSearchFilter: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The filter to find the groups of a user, with `%d` as placeholder for the user DN, e.g. `(&(objectclass=groupOfNames)(member=%d))`.",
+			},
+			keyLDAPConfigTLSSkipVerify: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disables the verification of the LDAP server certificate.",
+			},
+			keyLDAPConfigServerInsecure: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Connects to the LDAP server without TLS.",
+			},
+			keyLDAPConfigServerStartTLS: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Uses StartTLS to upgrade an unencrypted connection to the LDAP server.",
+			},
+		},
+	}
+	return target.resource("Manages the LDAP identity provider configuration of the server.\nThere is only one LDAP configuration per server, on deletion it is reset to its defaults.")
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyLDAPPolicyMappingUserDN   = "user_dn"
+	keyLDAPPolicyMappingGroupDN  = "group_dn"
+	keyLDAPPolicyMappingPolicies = "policies"
+)
+
+func schemaLDAPPolicyMapping() objectSchema {
+	return map[string]*schema.Schema{
+		keyLDAPPolicyMappingUserDN: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The DN of the LDAP user.",
+			ForceNew:     true,
+			ExactlyOneOf: []string{keyLDAPPolicyMappingUserDN, keyLDAPPolicyMappingGroupDN},
+		},
+		keyLDAPPolicyMappingGroupDN: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The DN of the LDAP group.",
+			ForceNew:     true,
+			ExactlyOneOf: []string{keyLDAPPolicyMappingUserDN, keyLDAPPolicyMappingGroupDN},
+		},
+		keyLDAPPolicyMappingPolicies: &schema.Schema{
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Required:    true,
+			MinItems:    1,
+			Description: "The names of the canned policies mapped to the user or group.",
+		},
+	}
+}
+
+func resourceLDAPPolicyMapping() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Maps canned policies to an LDAP user or group, identified by its DN.\nManages all policies of the DN, so there should only be one mapping per DN.",
+		CreateContext: resourceLDAPPolicyMappingCreate,
+		ReadContext:   resourceLDAPPolicyMappingRead,
+		UpdateContext: resourceLDAPPolicyMappingUpdate,
+		DeleteContext: resourceLDAPPolicyMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLDAPPolicyMappingImport,
+		},
+		Schema: schemaLDAPPolicyMapping(),
+	}
+}
+
+// Determine the DN of a mapping and whether it belongs to a group.
+func dataGetLDAPPolicyMappingDN(d *schema.ResourceData) (string, bool) {
+	if groupDN := d.Get(keyLDAPPolicyMappingGroupDN).(string); groupDN != "" {
+		return groupDN, true
+	}
+	return d.Get(keyLDAPPolicyMappingUserDN).(string), false
+}
+
+// Build the ID of a mapping in the form of user:<dn> or group:<dn>.
+func ldapPolicyMappingID(dn string, isGroup bool) string {
+	if isGroup {
+		return "group:" + dn
+	}
+	return "user:" + dn
+}
+
+// Import a mapping from an ID in the form of user:<dn> or group:<dn>.
+func resourceLDAPPolicyMappingImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	pieces := strings.SplitN(d.Id(), ":", 2)
+	if len(pieces) != 2 || pieces[1] == "" {
+		return nil, fmt.Errorf("Invalid ID '%s': expected user:<dn> or group:<dn>", d.Id())
+	}
+	switch pieces[0] {
+	case "user":
+		if err := d.Set(keyLDAPPolicyMappingUserDN, pieces[1]); err != nil {
+			return nil, err
+		}
+	case "group":
+		if err := d.Set(keyLDAPPolicyMappingGroupDN, pieces[1]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Invalid ID '%s': expected user:<dn> or group:<dn>", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// Load the policies currently mapped to an LDAP user or group.
+func ldapGetPolicies(ctx context.Context, m interface{}, dn string, isGroup bool) ([]string, error) {
+	client := m.(*minioContext).admin
+	query := madmin.PolicyEntitiesQuery{}
+	if isGroup {
+		query.Groups = []string{dn}
+	} else {
+		query.Users = []string{dn}
+	}
+	result, err := client.GetLDAPPolicyEntities(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load LDAP policy mappings of %s: %s", dn, err)
+	}
+
+	// The server normalizes DNs, so they are compared case insensitively.
+	if isGroup {
+		for _, mapping := range result.GroupMappings {
+			if strings.EqualFold(mapping.Group, dn) {
+				return mapping.Policies, nil
+			}
+		}
+	} else {
+		for _, mapping := range result.UserMappings {
+			if strings.EqualFold(mapping.User, dn) {
+				return mapping.Policies, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Change the mapped policies of the DN to the configured ones.
+func resourceLDAPPolicyMappingApply(ctx context.Context, d *schema.ResourceData, m interface{}, policies []string) error {
+	mctx := m.(*minioContext)
+	dn, isGroup := dataGetLDAPPolicyMappingDN(d)
+
+	unlock := mctx.lockPolicies()
+	defer unlock()
+
+	current, err := ldapGetPolicies(ctx, m, dn, isGroup)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Mapping policies %v to LDAP DN '%s'\n", policies, dn)
+	if err := mctx.updateLDAPEntityPolicies(ctx, dn, isGroup, current, policies); err != nil {
+		return fmt.Errorf("Could not map policies to %s: %s", dn, err)
+	}
+	return nil
+}
+
+func resourceLDAPPolicyMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	dn, isGroup := dataGetLDAPPolicyMappingDN(d)
+	policies := interfaceToStringSlice(d.Get(keyLDAPPolicyMappingPolicies).(*schema.Set).List())
+
+	if err := resourceLDAPPolicyMappingApply(ctx, d, m, policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ldapPolicyMappingID(dn, isGroup))
+	return resourceLDAPPolicyMappingRead(ctx, d, m)
+}
+
+func resourceLDAPPolicyMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	dn, isGroup := dataGetLDAPPolicyMappingDN(d)
+
+	policies, err := ldapGetPolicies(ctx, m, dn, isGroup)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(policies) == 0 {
+		log.Printf("[WARN] No policies are mapped to LDAP DN '%s' anymore\n", dn)
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set(keyLDAPPolicyMappingPolicies, policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceLDAPPolicyMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	policies := interfaceToStringSlice(d.Get(keyLDAPPolicyMappingPolicies).(*schema.Set).List())
+
+	if err := resourceLDAPPolicyMappingApply(ctx, d, m, policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLDAPPolicyMappingRead(ctx, d, m)
+}
+
+func resourceLDAPPolicyMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	mctx := m.(*minioContext)
+	dn, isGroup := dataGetLDAPPolicyMappingDN(d)
+
+	unlock := mctx.lockPolicies()
+	defer unlock()
+
+	current, err := ldapGetPolicies(ctx, m, dn, isGroup)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// SetPolicy can not remove all policies, so the mapping can only be
+	// removed with the detach API.
+	if len(current) > 0 {
+		log.Printf("[DEBUG] Detaching policies %v from LDAP DN '%s'\n", current, dn)
+		err := mctx.policyBuiltinApply(ctx, policyIDPLDAP, dn, isGroup, nil, current)
+		if err == errPolicyBuiltinUnsupported {
+			mctx.policyLDAPSupport = policyBuiltinUnsupported
+			return diag.Errorf("Could not remove the policy mapping of %s: the minio server does not support detaching LDAP policies. Remove the mapping on the server and the resource from the state manually.", dn)
+		}
+		if err != nil {
+			return diag.Errorf("Could not remove the policy mapping of %s: %s", dn, err)
+		}
+		mctx.policyLDAPSupport = policyBuiltinSupported
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The audit and logger webhook subsystems share the same configuration keys.
+
+const (
+	keyLogWebhookEndpoint   = "endpoint"
+	keyLogWebhookAuthToken  = "auth_token"
+	keyLogWebhookClientCert = "client_cert"
+	keyLogWebhookClientKey  = "client_key"
+	keyLogWebhookQueueSize  = "queue_size"
+	keyLogWebhookBatchSize  = "batch_size"
+)
+
+func schemaLogWebhook() objectSchema {
+	return map[string]*schema.Schema{
+		keyLogWebhookEndpoint: &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The URL the log entries are posted to.",
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+		keyLogWebhookAuthToken: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The token sent in the authorization header, e.g. `Bearer <token>`.",
+		},
+		keyLogWebhookClientCert: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path on the server to the client certificate for mTLS authentication.",
+		},
+		keyLogWebhookClientKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path on the server to the client key for mTLS authentication.",
+		},
+		keyLogWebhookQueueSize: &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			Description:  "The maximum number of log entries buffered in memory. The server default is used if not set.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		keyLogWebhookBatchSize: &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			Description:  "The number of log entries sent per request. The server default is used if not set.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+}
+
+func resourceAuditWebhook() *schema.Resource {
+	target := configTarget{
+		subsystem: "audit_webhook",
+		fields:    schemaLogWebhook(),
+	}
+	return target.resource("Manages a webhook target for audit logs, e.g. to ship them to a SIEM.\nUse `_` as name for the default target.")
+}
+
+func resourceLoggerWebhook() *schema.Resource {
+	target := configTarget{
+		subsystem: "logger_webhook",
+		fields:    schemaLogWebhook(),
+	}
+	return target.resource("Manages a webhook target for server logs.\nUse `_` as name for the default target.")
+}
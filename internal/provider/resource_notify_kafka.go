@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyNotifyKafkaBrokers       = "brokers"
+	keyNotifyKafkaTopic         = "topic"
+	keyNotifyKafkaSASL          = "sasl"
+	keyNotifyKafkaSASLUsername  = "sasl_username"
+	keyNotifyKafkaSASLPassword  = "sasl_password"
+	keyNotifyKafkaSASLMechanism = "sasl_mechanism"
+	keyNotifyKafkaTLS           = "tls"
+	keyNotifyKafkaTLSSkipVerify = "tls_skip_verify"
+	keyNotifyKafkaTLSClientAuth = "tls_client_auth"
+	keyNotifyKafkaClientTLSCert = "client_tls_cert"
+	keyNotifyKafkaClientTLSKey  = "client_tls_key"
+	keyNotifyKafkaVersion       = "version"
+)
+
+func resourceNotifyKafka() *schema.Resource {
+	target := configTarget{
+		subsystem: "notify_kafka",
+		arnType:   "kafka",
+		fields: withNotifyQueue(objectSchema{
+			keyNotifyKafkaBrokers: &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Required:    true,
+				MinItems:    1,
+				Description: "The Kafka brokers as `host:port`.",
+			},
+			keyNotifyKafkaTopic: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The topic the events are published to.",
+			},
+			keyNotifyKafkaSASL: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables SASL authentication.",
+			},
+			keyNotifyKafkaSASLUsername: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The SASL username.",
+			},
+			keyNotifyKafkaSASLPassword: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The SASL password.",
+			},
+			keyNotifyKafkaSASLMechanism: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "plain",
+				Description:  "The SASL mechanism: `plain`, `sha256` or `sha512`.",
+				ValidateFunc: validation.StringInSlice([]string{"plain", "sha256", "sha512"}, false),
+			},
+			keyNotifyKafkaTLS: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enables TLS for the connection to the brokers.",
+			},
+			keyNotifyKafkaTLSSkipVerify: &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disables the verification of the broker certificates.",
+			},
+			keyNotifyKafkaTLSClientAuth: &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The TLS client authentication type, from `0` (none) to `4` (require and verify).",
+				ValidateFunc: validation.IntBetween(0, 4),
+			},
+			keyNotifyKafkaClientTLSCert: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path on the server to the client certificate for mTLS authentication.",
+			},
+			keyNotifyKafkaClientTLSKey: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path on the server to the client key for mTLS authentication.",
+			},
+			keyNotifyKafkaVersion: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Kafka protocol version, e.g. `2.8.0`.",
+			},
+		}),
+	}
+	return target.resource("Manages a Kafka bucket notification target.\nUse `arn` in bucket notification configurations.")
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyNotifyWebhookEndpoint   = "endpoint"
+	keyNotifyWebhookAuthToken  = "auth_token"
+	keyNotifyWebhookClientCert = "client_cert"
+	keyNotifyWebhookClientKey  = "client_key"
+)
+
+func resourceNotifyWebhook() *schema.Resource {
+	target := configTarget{
+		subsystem: "notify_webhook",
+		arnType:   "webhook",
+		fields: withNotifyQueue(objectSchema{
+			keyNotifyWebhookEndpoint: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The URL the events are posted to.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			keyNotifyWebhookAuthToken: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The token sent in the authorization header, e.g. `Bearer <token>`.",
+			},
+			keyNotifyWebhookClientCert: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path on the server to the client certificate for mTLS authentication.",
+			},
+			keyNotifyWebhookClientKey: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path on the server to the client key for mTLS authentication.",
+			},
+		}),
+	}
+	return target.resource("Manages a webhook bucket notification target.\nUse `arn` in bucket notification configurations.")
+}
@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyObjectCopyBucket            = "bucket"
+	keyObjectCopyKey               = "key"
+	keyObjectCopySourceBucket      = "source_bucket"
+	keyObjectCopySourceKey         = "source_key"
+	keyObjectCopySourceVersionID   = "source_version_id"
+	keyObjectCopySourceCustomerKey = "source_customer_key"
+	keyObjectCopyMetadataDirective = "metadata_directive"
+	keyObjectCopyMetadata          = "metadata"
+	keyObjectCopyTaggingDirective  = "tagging_directive"
+	keyObjectCopyTags              = "tags"
+	keyObjectCopySSE               = "sse"
+	keyObjectCopyRetentionMode     = "retention_mode"
+	keyObjectCopyRetainUntil       = "retain_until"
+	keyObjectCopyVersionID         = "version_id"
+	keyObjectCopyETag              = "etag"
+	keyObjectCopySize              = "size"
+	keyObjectCopySourceETag        = "source_etag"
+)
+
+const (
+	directiveCopy    = "COPY"
+	directiveReplace = "REPLACE"
+)
+
+// Objects larger than this can not be copied in a single request.
+const objectCopyMaxSingleSize = 1024 * 1024 * 1024 * 5
+
+func schemaObjectCopy() objectSchema {
+	return map[string]*schema.Schema{
+		keyObjectCopyBucket: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The destination bucket.",
+			ForceNew:    true,
+		},
+		keyObjectCopyKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The destination key.",
+			ForceNew:    true,
+		},
+		keyObjectCopySourceBucket: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The source bucket.",
+			ForceNew:    true,
+		},
+		keyObjectCopySourceKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The source key.",
+			ForceNew:    true,
+		},
+		keyObjectCopySourceVersionID: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The version of the source object. Defaults to the latest version.",
+			ForceNew:    true,
+		},
+		keyObjectCopySourceCustomerKey: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			Description:  "The base64 encoded customer key of the source object, if it is encrypted with `SSE-C`.",
+			ForceNew:     true,
+			ValidateFunc: validateSSECustomerKey,
+		},
+		keyObjectCopyMetadataDirective: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      directiveCopy,
+			Description:  "`COPY` keeps the user metadata of the source object, `REPLACE` uses `metadata` instead.",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{directiveCopy, directiveReplace}, false),
+		},
+		keyObjectCopyMetadata: &schema.Schema{
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The user metadata of the copy, if `metadata_directive` is `REPLACE`.",
+			ForceNew:    true,
+		},
+		keyObjectCopyTaggingDirective: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      directiveCopy,
+			Description:  "`COPY` keeps the tags of the source object, `REPLACE` uses `tags` instead.",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{directiveCopy, directiveReplace}, false),
+		},
+		keyObjectCopyTags: &schema.Schema{
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The tags of the copy, if `tagging_directive` is `REPLACE`.",
+			ForceNew:    true,
+		},
+		keyObjectCopySSE: schemaSSE("Server side encryption of the copy.", true),
+		keyObjectCopyRetentionMode: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The retention mode of the copy, `GOVERNANCE` or `COMPLIANCE`. Requires object locking on the destination bucket.",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{minio.Governance.String(), minio.Compliance.String()}, false),
+			RequiredWith: []string{keyObjectCopyRetainUntil},
+		},
+		keyObjectCopyRetainUntil: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The end of the retention in RFC 3339 format.",
+			ForceNew:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+			RequiredWith: []string{keyObjectCopyRetentionMode},
+		},
+		keyObjectCopyVersionID: &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The version of the copy, if the destination bucket is versioned.",
+		},
+		keyObjectCopyETag: &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ETag of the copy.",
+		},
+		keyObjectCopySize: &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The size of the copy in bytes.",
+		},
+		keyObjectCopySourceETag: &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ETag of the source object at the time of the copy.\nThe object is copied again if the source object changes.",
+		},
+	}
+}
+
+func resourceObjectCopy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Copies an object on the server, without downloading it.",
+		CreateContext: resourceObjectCopyCreate,
+		ReadContext:   resourceObjectCopyRead,
+		DeleteContext: resourceObjectCopyDelete,
+		CustomizeDiff: resourceObjectCopyCustomizeDiff,
+		Schema:        schemaObjectCopy(),
+	}
+}
+
+// Copy the object again if the latest version of the source object changed.
+func resourceObjectCopyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.HasChange(keyObjectCopySourceBucket) || d.HasChange(keyObjectCopySourceKey) || d.Get(keyObjectCopySourceVersionID).(string) != "" {
+		return nil
+	}
+
+	client := m.(*minioContext).api
+	opts := minio.StatObjectOptions{}
+	if sourceKey := d.Get(keyObjectCopySourceCustomerKey).(string); sourceKey != "" {
+		sourceSSE, err := parseSSECustomerKey(sourceKey)
+		if err != nil {
+			return err
+		}
+		opts.ServerSideEncryption = sourceSSE
+	}
+	info, err := client.StatObject(ctx, d.Get(keyObjectCopySourceBucket).(string), d.Get(keyObjectCopySourceKey).(string), opts)
+	if err != nil {
+		// A missing source only matters once the object is copied again.
+		return nil
+	}
+
+	if info.ETag != d.Get(keyObjectCopySourceETag).(string) {
+		if err := d.SetNew(keyObjectCopySourceETag, info.ETag); err != nil {
+			return err
+		}
+		return d.ForceNew(keyObjectCopySourceETag)
+	}
+	return nil
+}
+
+func resourceObjectCopyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).api
+
+	sse, err := dataGetSSE(d, keyObjectCopySSE)
+	if err != nil {
+		return []diag.Diagnostic{diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: cty.GetAttrPath(keyObjectCopySSE),
+		}}
+	}
+
+	src := minio.CopySrcOptions{
+		Bucket:    d.Get(keyObjectCopySourceBucket).(string),
+		Object:    d.Get(keyObjectCopySourceKey).(string),
+		VersionID: d.Get(keyObjectCopySourceVersionID).(string),
+	}
+	var sourceSSE encrypt.ServerSide
+	if sourceKey := d.Get(keyObjectCopySourceCustomerKey).(string); sourceKey != "" {
+		if sourceSSE, err = parseSSECustomerKey(sourceKey); err != nil {
+			return diag.FromErr(err)
+		}
+		src.Encryption = encrypt.SSECopy(sourceSSE)
+	}
+	dst := minio.CopyDestOptions{
+		Bucket:     d.Get(keyObjectCopyBucket).(string),
+		Object:     d.Get(keyObjectCopyKey).(string),
+		Encryption: sse,
+	}
+	if d.Get(keyObjectCopyMetadataDirective).(string) == directiveReplace {
+		dst.ReplaceMetadata = true
+		dst.UserMetadata = dataGetStringMap(d, keyObjectCopyMetadata)
+	}
+	if d.Get(keyObjectCopyTaggingDirective).(string) == directiveReplace {
+		dst.ReplaceTags = true
+		dst.UserTags = dataGetStringMap(d, keyObjectCopyTags)
+	}
+	if mode := d.Get(keyObjectCopyRetentionMode).(string); mode != "" {
+		retainUntil, err := time.Parse(time.RFC3339, d.Get(keyObjectCopyRetainUntil).(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dst.Mode = minio.RetentionMode(mode)
+		dst.RetainUntilDate = retainUntil
+	}
+
+	// Pin the source to the version that was checked, so the recorded ETag
+	// matches the copy.
+	info, err := client.StatObject(ctx, src.Bucket, src.Object, minio.StatObjectOptions{
+		VersionID:            src.VersionID,
+		ServerSideEncryption: sourceSSE,
+	})
+	if err != nil {
+		return diag.Errorf("Could not load source object %s/%s: %s", src.Bucket, src.Object, err)
+	}
+	src.MatchETag = info.ETag
+
+	// Objects above the single request limit are copied in parts.
+	if info.Size > objectCopyMaxSingleSize {
+		_, err = client.ComposeObject(ctx, dst, src)
+	} else {
+		_, err = client.CopyObject(ctx, dst, src)
+	}
+	if err != nil {
+		return diag.Errorf("Could not copy %s/%s to %s/%s: %s", src.Bucket, src.Object, dst.Bucket, dst.Object, err)
+	}
+
+	if err := d.Set(keyObjectCopySourceETag, info.ETag); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dst.Bucket + "/" + dst.Object)
+	return resourceObjectCopyRead(ctx, d, m)
+}
+
+func resourceObjectCopyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyObjectCopyBucket).(string)
+	key := d.Get(keyObjectCopyKey).(string)
+
+	// Objects encrypted with SSE-C can only be read with their key.
+	// Errors are ignored, since the block can hold an observed type that
+	// differs from the configuration.
+	sse, _ := dataGetSSE(d, keyObjectCopySSE)
+
+	info, err := client.StatObject(ctx, bucket, key, minio.StatObjectOptions{ServerSideEncryption: sse})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			// The copy was deleted outside of terraform.
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("Could not load object %s/%s: %s", bucket, key, err)
+	}
+
+	err = dataSetValues(d, map[string]interface{}{
+		keyObjectCopyVersionID: info.VersionID,
+		keyObjectCopyETag:      info.ETag,
+		keyObjectCopySize:      int(info.Size),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := dataSetSSEType(d, keyObjectCopySSE, sseTypeFromHeaders(info.Metadata)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceObjectCopyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyObjectCopyBucket).(string)
+	key := d.Get(keyObjectCopyKey).(string)
+
+	if err := client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyObjectGovernanceBucket           = "bucket"
+	keyObjectGovernanceKey              = "key"
+	keyObjectGovernanceVersionID        = "version_id"
+	keyObjectGovernanceTags             = "tags"
+	keyObjectGovernanceRetention        = "retention"
+	keyObjectGovernanceLegalHold        = "legal_hold"
+	keyObjectGovernanceBypassGovernance = "bypass_governance"
+
+	keyRetentionMode        = "mode"
+	keyRetentionRetainUntil = "retain_until"
+)
+
+// Error codes returned when an object has no retention or legal hold.
+// Other errors, like an unknown version, are reported.
+var objectLockNotFoundCodes = []string{
+	"NoSuchObjectLockConfiguration",
+}
+
+// Determine if object lock is enabled on a bucket.
+// Objects in other buckets can not have a retention or legal hold.
+func objectLockEnabled(ctx context.Context, client *minio.Client, bucket string) (bool, error) {
+	objectLock, _, _, _, err := client.GetObjectLockConfig(ctx, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "ObjectLockConfigurationNotFoundError" {
+			return false, nil
+		}
+		return false, err
+	}
+	return objectLock == "Enabled", nil
+}
+
+func schemaObjectGovernance() objectSchema {
+	return map[string]*schema.Schema{
+		keyObjectGovernanceBucket: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the bucket.",
+			ForceNew:    true,
+		},
+		keyObjectGovernanceKey: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The key of the object.",
+			ForceNew:    true,
+		},
+		keyObjectGovernanceVersionID: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The version of the object. Defaults to the latest version.",
+			ForceNew:    true,
+		},
+		keyObjectGovernanceTags: &schema.Schema{
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The tags of the object. Tags not in this map are removed.",
+		},
+		keyObjectGovernanceRetention: &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "The retention of the object. Requires object locking on the bucket.",
+			Elem: &schema.Resource{
+				Schema: objectSchema{
+					keyRetentionMode: &schema.Schema{
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The retention mode, `GOVERNANCE` or `COMPLIANCE`.\n`COMPLIANCE` retention can not be shortened or removed by anyone.",
+						ValidateFunc: validation.StringInSlice([]string{minio.Governance.String(), minio.Compliance.String()}, false),
+					},
+					keyRetentionRetainUntil: &schema.Schema{
+						Type:             schema.TypeString,
+						Required:         true,
+						Description:      "The end of the retention in RFC 3339 format.",
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppressEqualTimeDiff,
+					},
+				},
+			},
+		},
+		keyObjectGovernanceLegalHold: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Places a legal hold on the object, which prevents its deletion until the hold is removed.",
+		},
+		keyObjectGovernanceBypassGovernance: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Must be true to shorten or remove `GOVERNANCE` retention.",
+		},
+	}
+}
+
+func resourceObjectGovernance() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the tags, retention and legal hold of an existing object.\nOn deletion, the tags and the legal hold are removed, while the retention is kept.",
+		CreateContext: resourceObjectGovernanceCreate,
+		ReadContext:   resourceObjectGovernanceRead,
+		UpdateContext: resourceObjectGovernanceUpdate,
+		DeleteContext: resourceObjectGovernanceDelete,
+		CustomizeDiff: resourceObjectGovernanceCustomizeDiff,
+		Schema:        schemaObjectGovernance(),
+	}
+}
+
+// Ignore differences in the formatting of equal points in time.
+func suppressEqualTimeDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+// Parse a retention block.
+// Returns an empty mode if the block is not set.
+func parseRetention(raw interface{}) (minio.RetentionMode, time.Time, error) {
+	blocks := raw.([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return "", time.Time{}, nil
+	}
+	block := blocks[0].(map[string]interface{})
+	retainUntil, err := time.Parse(time.RFC3339, block[keyRetentionRetainUntil].(string))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return minio.RetentionMode(block[keyRetentionMode].(string)), retainUntil, nil
+}
+
+// Refuse changes that weaken the retention of an object, unless the governance
+// mode is explicitly bypassed.
+func resourceObjectGovernanceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange(keyObjectGovernanceRetention) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(keyObjectGovernanceRetention)
+	oldMode, oldRetainUntil, err := parseRetention(oldRaw)
+	if err != nil {
+		return err
+	}
+	newMode, newRetainUntil, err := parseRetention(newRaw)
+	if err != nil {
+		// Not known yet.
+		return nil
+	}
+
+	weakened := newMode == "" || newRetainUntil.Before(oldRetainUntil) || (oldMode == minio.Compliance && newMode != minio.Compliance)
+	if !weakened {
+		return nil
+	}
+	switch oldMode {
+	case minio.Compliance:
+		return fmt.Errorf("The COMPLIANCE retention of the object can not be shortened or removed")
+	case minio.Governance:
+		if !d.Get(keyObjectGovernanceBypassGovernance).(bool) {
+			return fmt.Errorf("Shortening or removing the GOVERNANCE retention of the object requires %s to be true", keyObjectGovernanceBypassGovernance)
+		}
+	}
+	return nil
+}
+
+// Apply the tags of the object.
+func objectGovernanceUpdateTags(ctx context.Context, d *schema.ResourceData, client *minio.Client) error {
+	bucket := d.Get(keyObjectGovernanceBucket).(string)
+	key := d.Get(keyObjectGovernanceKey).(string)
+	versionID := d.Get(keyObjectGovernanceVersionID).(string)
+
+	tagMap := dataGetStringMap(d, keyObjectGovernanceTags)
+	if len(tagMap) == 0 {
+		return client.RemoveObjectTagging(ctx, bucket, key, minio.RemoveObjectTaggingOptions{VersionID: versionID})
+	}
+	objectTags, err := tags.MapToObjectTags(tagMap)
+	if err != nil {
+		return err
+	}
+	return client.PutObjectTagging(ctx, bucket, key, objectTags, minio.PutObjectTaggingOptions{VersionID: versionID})
+}
+
+// Apply the retention of the object.
+func objectGovernanceUpdateRetention(ctx context.Context, d *schema.ResourceData, client *minio.Client) error {
+	mode, retainUntil, err := parseRetention(d.Get(keyObjectGovernanceRetention))
+	if err != nil {
+		return err
+	}
+	opts := minio.PutObjectRetentionOptions{
+		GovernanceBypass: d.Get(keyObjectGovernanceBypassGovernance).(bool),
+		VersionID:        d.Get(keyObjectGovernanceVersionID).(string),
+	}
+	if mode != "" {
+		opts.Mode = &mode
+		opts.RetainUntilDate = &retainUntil
+	}
+	return client.PutObjectRetention(ctx, d.Get(keyObjectGovernanceBucket).(string), d.Get(keyObjectGovernanceKey).(string), opts)
+}
+
+// Apply the legal hold of the object.
+func objectGovernanceUpdateLegalHold(ctx context.Context, d *schema.ResourceData, client *minio.Client, enabled bool) error {
+	status := minio.LegalHoldDisabled
+	if enabled {
+		status = minio.LegalHoldEnabled
+	}
+	return client.PutObjectLegalHold(ctx, d.Get(keyObjectGovernanceBucket).(string), d.Get(keyObjectGovernanceKey).(string), minio.PutObjectLegalHoldOptions{
+		VersionID: d.Get(keyObjectGovernanceVersionID).(string),
+		Status:    &status,
+	})
+}
+
+func objectGovernanceAttributeError(key string, err error) diag.Diagnostics {
+	return []diag.Diagnostic{diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       err.Error(),
+		AttributePath: cty.GetAttrPath(key),
+	}}
+}
+
+func resourceObjectGovernanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).api
+	bucket := d.Get(keyObjectGovernanceBucket).(string)
+	key := d.Get(keyObjectGovernanceKey).(string)
+	versionID := d.Get(keyObjectGovernanceVersionID).(string)
+
+	if len(d.Get(keyObjectGovernanceTags).(map[string]interface{})) > 0 {
+		if err := objectGovernanceUpdateTags(ctx, d, client); err != nil {
+			return objectGovernanceAttributeError(keyObjectGovernanceTags, err)
+		}
+	}
+	if len(d.Get(keyObjectGovernanceRetention).([]interface{})) > 0 {
+		if err := objectGovernanceUpdateRetention(ctx, d, client); err != nil {
+			return objectGovernanceAttributeError(keyObjectGovernanceRetention, err)
+		}
+	}
+	if d.Get(keyObjectGovernanceLegalHold).(bool) {
+		if err := objectGovernanceUpdateLegalHold(ctx, d, client, true); err != nil {
+			return objectGovernanceAttributeError(keyObjectGovernanceLegalHold, err)
+		}
+	}
+
+	id := bucket + "/" + key
+	if versionID != "" {
+		id += "@" + versionID
+	}
+	d.SetId(id)
+	return resourceObjectGovernanceRead(ctx, d, m)
+}
+
+func resourceObjectGovernanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyObjectGovernanceBucket).(string)
+	key := d.Get(keyObjectGovernanceKey).(string)
+	versionID := d.Get(keyObjectGovernanceVersionID).(string)
+
+	objectTags, err := client.GetObjectTagging(ctx, bucket, key, minio.GetObjectTaggingOptions{VersionID: versionID})
+	if err != nil {
+		code := minio.ToErrorResponse(err).Code
+		if code == "NoSuchKey" || code == "NoSuchVersion" {
+			// The object was deleted outside of terraform.
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("Could not load tags of object %s/%s: %s", bucket, key, err)
+	}
+
+	locked, err := objectLockEnabled(ctx, client, bucket)
+	if err != nil {
+		return diag.Errorf("Could not load object lock configuration of bucket %s: %s", bucket, err)
+	}
+
+	retention := []interface{}{}
+	legalHold := false
+	if locked {
+		mode, retainUntil, err := client.GetObjectRetention(ctx, bucket, key, versionID)
+		if err != nil && !stringSliceContains(objectLockNotFoundCodes, minio.ToErrorResponse(err).Code) {
+			return diag.Errorf("Could not load retention of object %s/%s: %s", bucket, key, err)
+		}
+		if err == nil && mode != nil && retainUntil != nil {
+			retention = append(retention, map[string]interface{}{
+				keyRetentionMode:        mode.String(),
+				keyRetentionRetainUntil: retainUntil.UTC().Format(time.RFC3339),
+			})
+		}
+
+		status, err := client.GetObjectLegalHold(ctx, bucket, key, minio.GetObjectLegalHoldOptions{VersionID: versionID})
+		if err != nil && !stringSliceContains(objectLockNotFoundCodes, minio.ToErrorResponse(err).Code) {
+			return diag.Errorf("Could not load legal hold of object %s/%s: %s", bucket, key, err)
+		}
+		if err == nil && status != nil {
+			legalHold = *status == minio.LegalHoldEnabled
+		}
+	}
+
+	err = dataSetValues(d, map[string]interface{}{
+		keyObjectGovernanceTags:      objectTags.ToMap(),
+		keyObjectGovernanceRetention: retention,
+		keyObjectGovernanceLegalHold: legalHold,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceObjectGovernanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).api
+
+	if d.HasChange(keyObjectGovernanceTags) {
+		if err := objectGovernanceUpdateTags(ctx, d, client); err != nil {
+			return objectGovernanceAttributeError(keyObjectGovernanceTags, err)
+		}
+	}
+	if d.HasChange(keyObjectGovernanceRetention) {
+		if err := objectGovernanceUpdateRetention(ctx, d, client); err != nil {
+			return objectGovernanceAttributeError(keyObjectGovernanceRetention, err)
+		}
+	}
+	if d.HasChange(keyObjectGovernanceLegalHold) {
+		if err := objectGovernanceUpdateLegalHold(ctx, d, client, d.Get(keyObjectGovernanceLegalHold).(bool)); err != nil {
+			return objectGovernanceAttributeError(keyObjectGovernanceLegalHold, err)
+		}
+	}
+
+	return resourceObjectGovernanceRead(ctx, d, m)
+}
+
+func resourceObjectGovernanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).api
+	bucket := d.Get(keyObjectGovernanceBucket).(string)
+	key := d.Get(keyObjectGovernanceKey).(string)
+	versionID := d.Get(keyObjectGovernanceVersionID).(string)
+
+	if len(d.Get(keyObjectGovernanceTags).(map[string]interface{})) > 0 {
+		if err := client.RemoveObjectTagging(ctx, bucket, key, minio.RemoveObjectTaggingOptions{VersionID: versionID}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.Get(keyObjectGovernanceLegalHold).(bool) {
+		if err := objectGovernanceUpdateLegalHold(ctx, d, client, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
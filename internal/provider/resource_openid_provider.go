@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyOpenIDProviderConfigURL    = "config_url"
+	keyOpenIDProviderClientID     = "client_id"
+	keyOpenIDProviderClientSecret = "client_secret"
+	keyOpenIDProviderClaimName    = "claim_name"
+	keyOpenIDProviderClaimPrefix  = "claim_prefix"
+	keyOpenIDProviderScopes       = "scopes"
+	keyOpenIDProviderRedirectURI  = "redirect_uri"
+	keyOpenIDProviderRolePolicy   = "role_policy"
+	keyOpenIDProviderDisplayName  = "display_name"
+)
+
+func resourceOpenIDProvider() *schema.Resource {
+	target := configTarget{
+		subsystem: "identity_openid",
+		fields: objectSchema{
+			keyOpenIDProviderConfigURL: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The OpenID discovery document URL, e.g. `https://accounts.example.com/.well-known/openid-configuration`.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			keyOpenIDProviderClientID: &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The client ID registered at the provider.",
+			},
+			keyOpenIDProviderClientSecret: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The client secret registered at the provider.",
+			},
+			keyOpenIDProviderClaimName: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The JWT claim that contains the policies of a user. The server default is `policy`.",
+				ConflictsWith: []string{keyOpenIDProviderRolePolicy},
+			},
+			keyOpenIDProviderClaimPrefix: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The prefix added to the policies of the claim.",
+			},
+			keyOpenIDProviderScopes: &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Optional:    true,
+				Description: "The scopes requested from the provider, e.g. `openid` and `email`.\nThe scopes of the discovery document are used if not set.",
+			},
+			keyOpenIDProviderRedirectURI: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The redirect URI of the console, e.g. `https://console.example.com/oauth_callback`.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			keyOpenIDProviderRolePolicy: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Comma separated policies that are applied to all users of the provider, instead of a claim.",
+				ConflictsWith: []string{keyOpenIDProviderClaimName},
+			},
+			keyOpenIDProviderDisplayName: &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the provider shown on the console login page.",
+			},
+		},
+	}
+	return target.resource("Manages an OpenID Connect identity provider for single sign on.\nUse `_` as name for the default provider.")
+}
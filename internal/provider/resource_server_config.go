@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyServerConfigSubsystem       = "subsystem"
+	keyServerConfigTarget          = "target"
+	keyServerConfigValues          = "values"
+	keyServerConfigSensitiveValues = "sensitive_values"
+	keyServerConfigRestartRequired = "restart_required"
+)
+
+func schemaServerConfig() objectSchema {
+	return map[string]*schema.Schema{
+		keyServerConfigSubsystem: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The configuration subsystem, e.g. `api`, `scanner` or `notify_webhook`.",
+			ForceNew:    true,
+		},
+		keyServerConfigTarget: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The target within the subsystem, e.g. `primary` for `notify_webhook:primary`.\nEmpty for the default target.",
+			ForceNew:    true,
+		},
+		keyServerConfigValues: &schema.Schema{
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The configuration values, keyed by configuration key.\nKeys that are removed from the map are reset to their defaults, keys that are not listed are not changed.",
+		},
+		keyServerConfigSensitiveValues: &schema.Schema{
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Like `values`, but hidden in the plan output.\nChanges made outside of terraform are not detected, since the server can redact these values.",
+		},
+		keyServerConfigRestartRequired: &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if the server must be restarted to apply the last change.",
+		},
+	}
+}
+
+func resourceServerConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages configuration values of a server configuration subsystem, like `mc admin config set`.\nOn deletion, the managed keys are reset to their defaults.",
+		CreateContext: resourceServerConfigCreate,
+		ReadContext:   resourceServerConfigRead,
+		UpdateContext: resourceServerConfigUpdate,
+		DeleteContext: resourceServerConfigDelete,
+		CustomizeDiff: resourceServerConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerConfigImport,
+		},
+		Schema: schemaServerConfig(),
+	}
+}
+
+func resourceServerConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.HasChange(keyServerConfigValues) || d.HasChange(keyServerConfigSensitiveValues) {
+		return d.SetNewComputed(keyServerConfigRestartRequired)
+	}
+	return nil
+}
+
+// Import a configuration by its name, e.g. `notify_webhook:primary`.
+// All current values of the target are imported.
+func resourceServerConfigImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	pieces := strings.SplitN(d.Id(), madmin.SubSystemSeparator, 2)
+	if err := d.Set(keyServerConfigSubsystem, pieces[0]); err != nil {
+		return nil, err
+	}
+	if len(pieces) == 2 {
+		if err := d.Set(keyServerConfigTarget, pieces[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := configKVGet(ctx, m.(*minioContext).admin, pieces[0], d.Get(keyServerConfigTarget).(string))
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set(keyServerConfigValues, values); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// Merge the plain and sensitive configuration values.
+func dataGetServerConfigValues(d *schema.ResourceData) map[string]string {
+	values := dataGetStringMap(d, keyServerConfigValues)
+	for key, value := range dataGetStringMap(d, keyServerConfigSensitiveValues) {
+		values[key] = value
+	}
+	return values
+}
+
+func resourceServerConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	subsystem := d.Get(keyServerConfigSubsystem).(string)
+	target := d.Get(keyServerConfigTarget).(string)
+
+	restart, err := configKVSet(ctx, client, subsystem, target, dataGetServerConfigValues(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyServerConfigRestartRequired, restart); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(configKVName(subsystem, target))
+	diags := configKVRestartWarning(restart, subsystem, target)
+	return append(diags, resourceServerConfigRead(ctx, d, m)...)
+}
+
+func resourceServerConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	subsystem := d.Get(keyServerConfigSubsystem).(string)
+	target := d.Get(keyServerConfigTarget).(string)
+
+	current, err := configKVGet(ctx, client, subsystem, target)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if current == nil {
+		// The target was removed outside of terraform.
+		d.SetId("")
+		return diags
+	}
+
+	// Only the managed keys are read, since the server also returns the
+	// defaults of all other keys.
+	values := make(map[string]string)
+	for key := range dataGetStringMap(d, keyServerConfigValues) {
+		if value, ok := current[key]; ok {
+			values[key] = value
+		}
+	}
+	if err := d.Set(keyServerConfigValues, values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceServerConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	subsystem := d.Get(keyServerConfigSubsystem).(string)
+	target := d.Get(keyServerConfigTarget).(string)
+
+	values := dataGetServerConfigValues(d)
+	var removed []string
+	for _, key := range []string{keyServerConfigValues, keyServerConfigSensitiveValues} {
+		oldRaw, _ := d.GetChange(key)
+		for oldKey := range oldRaw.(map[string]interface{}) {
+			if _, ok := values[oldKey]; !ok && !stringSliceContains(removed, oldKey) {
+				removed = append(removed, oldKey)
+			}
+		}
+	}
+
+	restart := false
+	if len(values) > 0 {
+		setRestart, err := configKVSet(ctx, client, subsystem, target, values)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		restart = restart || setRestart
+	}
+	if len(removed) > 0 {
+		deleteRestart, err := configKVDelete(ctx, client, subsystem, target, removed...)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		restart = restart || deleteRestart
+	}
+	if err := d.Set(keyServerConfigRestartRequired, restart); err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := configKVRestartWarning(restart, subsystem, target)
+	return append(diags, resourceServerConfigRead(ctx, d, m)...)
+}
+
+func resourceServerConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+	subsystem := d.Get(keyServerConfigSubsystem).(string)
+	target := d.Get(keyServerConfigTarget).(string)
+
+	// Only the managed keys are reset, other settings of the target are kept.
+	var keys []string
+	for key := range dataGetServerConfigValues(d) {
+		keys = append(keys, key)
+	}
+
+	restart := false
+	if len(keys) > 0 {
+		var err error
+		restart, err = configKVDelete(ctx, client, subsystem, target, keys...)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return configKVRestartWarning(restart, subsystem, target)
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keySiteReplicationSites          = "site"
+	keySiteReplicationUnmanagedSites = "unmanaged_sites"
+
+	keySiteName         = "name"
+	keySiteEndpoint     = "endpoint"
+	keySiteAccessKey    = "access_key"
+	keySiteSecretKey    = "secret_key"
+	keySiteDeploymentID = "deployment_id"
+)
+
+func schemaSiteReplication() objectSchema {
+	return map[string]*schema.Schema{
+		keySiteReplicationSites: &schema.Schema{
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    2,
+			Description: "The sites that replicate each other, including the site the provider is connected to.",
+			Elem: &schema.Resource{
+				Schema: objectSchema{
+					keySiteName: &schema.Schema{
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The unique name of the site.",
+						ValidateFunc: validation.NoZeroValues,
+					},
+					keySiteEndpoint: &schema.Schema{
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The endpoint of the site, including the scheme.",
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					},
+					keySiteAccessKey: &schema.Schema{
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The access key of an admin user of the site.\nOnly used when the site is added.",
+					},
+					keySiteSecretKey: &schema.Schema{
+						Type:        schema.TypeString,
+						Required:    true,
+						Sensitive:   true,
+						Description: "The secret key of an admin user of the site.\nOnly used when the site is added.",
+					},
+					keySiteDeploymentID: &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The deployment ID of the site.",
+					},
+				},
+			},
+		},
+		keySiteReplicationUnmanagedSites: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Computed:    true,
+			Description: "The names of sites that replicate with the configured sites, but are not configured.\nThey were added outside of terraform and are not removed.",
+		},
+	}
+}
+
+func resourceSiteReplication() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages site replication between multiple deployments, like `mc admin replicate`.\nSites are matched by name: new sites are added, missing sites are removed and changed endpoints are updated.\nOn deletion, site replication is removed from all sites.",
+		CreateContext: resourceSiteReplicationCreate,
+		ReadContext:   resourceSiteReplicationRead,
+		UpdateContext: resourceSiteReplicationUpdate,
+		DeleteContext: resourceSiteReplicationDelete,
+		Schema:        schemaSiteReplication(),
+	}
+}
+
+// Normalize an endpoint for comparison, since the server can report it with
+// a default port or without a trailing slash.
+func normalizeSiteEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	return scheme + "://" + host + strings.TrimSuffix(u.Path, "/")
+}
+
+// Load the configured sites, keyed by name.
+func dataGetSites(sites []interface{}) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{})
+	for _, rawSite := range sites {
+		site := rawSite.(map[string]interface{})
+		result[site[keySiteName].(string)] = site
+	}
+	return result
+}
+
+// Add all configured sites to site replication.
+// Sites that already replicate each other are left untouched.
+func siteReplicationAdd(ctx context.Context, client *madmin.AdminClient, sites []interface{}) error {
+	var peers []madmin.PeerSite
+	for _, rawSite := range sites {
+		site := rawSite.(map[string]interface{})
+		peers = append(peers, madmin.PeerSite{
+			Name:      site[keySiteName].(string),
+			Endpoint:  site[keySiteEndpoint].(string),
+			AccessKey: site[keySiteAccessKey].(string),
+			SecretKey: site[keySiteSecretKey].(string),
+		})
+	}
+
+	status, err := client.SiteReplicationAdd(ctx, peers)
+	if err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("%s %s", status.Status, status.ErrDetail)
+	}
+	if status.InitialSyncErrorMessage != "" {
+		log.Printf("[WARN] Initial site replication sync failed: %s\n", status.InitialSyncErrorMessage)
+	}
+	return nil
+}
+
+func siteReplicationRemove(ctx context.Context, client *madmin.AdminClient, request madmin.SRRemoveReq) error {
+	status, err := client.SiteReplicationRemove(ctx, request)
+	if err != nil {
+		return err
+	}
+	if status.Status != madmin.ReplicateRemoveStatusSuccess {
+		return fmt.Errorf("%s %s", status.Status, status.ErrDetail)
+	}
+	return nil
+}
+
+func resourceSiteReplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+
+	if err := siteReplicationAdd(ctx, client, d.Get(keySiteReplicationSites).([]interface{})); err != nil {
+		return diag.Errorf("Could not set up site replication: %s", err)
+	}
+
+	info, err := client.SiteReplicationInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Could not load site replication info: %s", err)
+	}
+
+	// The name of the local site identifies the replication.
+	d.SetId(info.Name)
+	return resourceSiteReplicationRead(ctx, d, m)
+}
+
+func resourceSiteReplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	info, err := client.SiteReplicationInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Could not load site replication info: %s", err)
+	}
+	if !info.Enabled {
+		log.Printf("[WARN] Site replication is no longer enabled\n")
+		d.SetId("")
+		return diags
+	}
+
+	// Keep the configured order and credentials, which can not be read back.
+	// Configured endpoints are kept if they are equivalent to the reported
+	// ones.
+	configured := d.Get(keySiteReplicationSites).([]interface{})
+	peers := make(map[string]madmin.PeerInfo)
+	for _, peer := range info.Sites {
+		peers[peer.Name] = peer
+	}
+	var sites []interface{}
+	for _, rawSite := range configured {
+		site := rawSite.(map[string]interface{})
+		peer, ok := peers[site[keySiteName].(string)]
+		if !ok {
+			continue
+		}
+		delete(peers, peer.Name)
+		if normalizeSiteEndpoint(site[keySiteEndpoint].(string)) != normalizeSiteEndpoint(peer.Endpoint) {
+			site[keySiteEndpoint] = peer.Endpoint
+		}
+		site[keySiteDeploymentID] = peer.DeploymentID
+		sites = append(sites, site)
+	}
+
+	// Sites added outside of terraform can not be part of the site list,
+	// since their credentials are unknown.
+	unmanaged := []string{}
+	for _, peer := range info.Sites {
+		if _, ok := peers[peer.Name]; ok {
+			unmanaged = append(unmanaged, peer.Name)
+		}
+	}
+	sort.Strings(unmanaged)
+	if len(unmanaged) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Sites %v replicate with the configured sites, but are not configured", unmanaged),
+		})
+	}
+
+	if err := dataSetValues(d, map[string]interface{}{
+		keySiteReplicationSites:          sites,
+		keySiteReplicationUnmanagedSites: unmanaged,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSiteReplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*minioContext).admin
+
+	oldRaw, newRaw := d.GetChange(keySiteReplicationSites)
+	oldSites := dataGetSites(oldRaw.([]interface{}))
+	newSites := dataGetSites(newRaw.([]interface{}))
+
+	var removed []string
+	for name := range oldSites {
+		if _, ok := newSites[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	added := false
+	for name, site := range newSites {
+		oldSite, ok := oldSites[name]
+		if !ok {
+			added = true
+			continue
+		}
+		if normalizeSiteEndpoint(site[keySiteEndpoint].(string)) == normalizeSiteEndpoint(oldSite[keySiteEndpoint].(string)) {
+			continue
+		}
+		log.Printf("[DEBUG] Changing endpoint of site '%s'\n", name)
+		status, err := client.SiteReplicationEdit(ctx, madmin.PeerInfo{
+			Name:         name,
+			Endpoint:     site[keySiteEndpoint].(string),
+			DeploymentID: oldSite[keySiteDeploymentID].(string),
+		})
+		if err != nil {
+			return diag.Errorf("Could not change endpoint of site %s: %s", name, err)
+		}
+		if !status.Success {
+			return diag.Errorf("Could not change endpoint of site %s: %s %s", name, status.Status, status.ErrDetail)
+		}
+	}
+
+	if len(removed) > 0 {
+		log.Printf("[DEBUG] Removing sites %v from site replication\n", removed)
+		if err := siteReplicationRemove(ctx, client, madmin.SRRemoveReq{SiteNames: removed}); err != nil {
+			return diag.Errorf("Could not remove sites %v: %s", removed, err)
+		}
+	}
+	if added {
+		if err := siteReplicationAdd(ctx, client, newRaw.([]interface{})); err != nil {
+			return diag.Errorf("Could not add sites: %s", err)
+		}
+	}
+
+	return resourceSiteReplicationRead(ctx, d, m)
+}
+
+func resourceSiteReplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+
+	if err := siteReplicationRemove(ctx, client, madmin.SRRemoveReq{RemoveAll: true}); err != nil {
+		return diag.Errorf("Could not remove site replication: %s", err)
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"log"
+	"math/big"
 	"strings"
 
 	// Minio ADMIN client SDK
@@ -22,8 +24,20 @@ const (
 	keySecretKey    = "secret_key"
 	keyUserPolicies = "policies"
 	keyUserGroups   = "groups"
+	keyUserEnabled  = "enabled"
+	keyUserStrict   = "strict"
+
+	keyUserSecretKeyVersion   = "secret_key_version"
+	keyUserStoreSecretKey     = "store_secret_key"
+	keyUserSecretKeyGenerated = "secret_key_generated"
 )
 
+// Characters used for generated secret keys.
+const secretKeyAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Length of generated secret keys. This is the maximum minio allows.
+const secretKeyLength = 40
+
 func schemaUser() objectSchema {
 	return map[string]*schema.Schema{
 		keyAccessKey: &schema.Schema{
@@ -33,27 +47,58 @@ func schemaUser() objectSchema {
 			ForceNew:    true,
 		},
 		keySecretKey: &schema.Schema{
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The secret key for the user.",
-			Sensitive:   true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			Description:      "The secret key for the user.\nIf not specified, a random secret key is generated.",
+			Sensitive:        true,
+			DiffSuppressFunc: suppressUnstoredSecretKeyDiff,
 		},
 		keyUserPolicies: &schema.Schema{
-			Type: schema.TypeList,
+			Type: schema.TypeSet,
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
 			Optional:    true,
-			Description: "The names of the canned policies valid for this user.",
+			Computed:    true,
+			Description: "The names of the canned policies valid for this user.\nIf not specified, the policies are not managed by this resource, e.g. because they are attached with `minio_user_policy_attachment`. An empty list is treated the same way.\nIf specified, this is the full list of policies and all other policies are detached, so it must not be combined with `minio_user_policy_attachment` for the same user.",
 		},
 		keyUserGroups: &schema.Schema{
-			Type: schema.TypeList,
+			Type: schema.TypeSet,
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
 			Optional:    true,
 			Description: "The names of the groups this user belongs to.",
 		},
+		keyUserEnabled: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "If false, the user is disabled and can not access the server.",
+		},
+		keyUserSecretKeyVersion: &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Changing this value rotates the secret key.\nA new secret key is generated if it was generated before, otherwise the configured `secret_key` is applied again.",
+		},
+		keyUserStoreSecretKey: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "If false, the secret key is not saved in the Terraform state.\nRequires `secret_key` to be set, since a generated secret key could not be returned: the provider does not support ephemeral values, so every returned value is saved in the state. Changes to `secret_key` are then only applied when `secret_key_version` changes.",
+		},
+		keyUserStrict: &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, the user is removed again if assigning policies or groups fails during creation, and an error is returned instead of a warning.\nThe provider level `strict` setting enables this for all users.",
+		},
+		keyUserSecretKeyGenerated: &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if the secret key was generated by the provider.",
+		},
 	}
 }
 
@@ -63,17 +108,100 @@ func resourceUser() *schema.Resource {
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		CustomizeDiff: resourceUserCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: schemaUser(),
+		Schema:        schemaUser(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceUserV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceUserStateUpgradeV0,
+			},
+		},
+	}
+}
+
+// Version 0 of the schema used lists for policies and groups.
+// This is a frozen copy, it must not change with the current schema.
+func resourceUserV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"access_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The access key for the user. This is also the unique ID.",
+				ForceNew:    true,
+			},
+			"secret_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The secret key for the user.\nIf not specified, a random secret key is generated.",
+				Sensitive:   true,
+			},
+			"policies": &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The names of the canned policies valid for this user.",
+			},
+			"groups": &schema.Schema{
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The names of the groups this user belongs to.",
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If false, the user is disabled and can not access the server.",
+			},
+			"secret_key_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Changing this value rotates the secret key.\nA new secret key is generated if it was generated before, otherwise the configured `secret_key` is applied again.",
+			},
+			"store_secret_key": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If false, the secret key is not saved in the Terraform state.\nRequires `secret_key` to be set, since a generated secret key could not be returned: the provider does not support ephemeral values, so every returned value is saved in the state. Changes to `secret_key` are then only applied when `secret_key_version` changes.",
+			},
+			"secret_key_generated": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the secret key was generated by the provider.",
+			},
+		},
 	}
 }
 
+func resourceUserStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	stateUpgradeStringListToSet(rawState, keyUserPolicies)
+	stateUpgradeStringListToSet(rawState, keyUserGroups)
+	return rawState, nil
+}
+
 func datasourceUser() *schema.Resource {
 	s := schemaUser()
-	s[keySecretKey].Required = false
-	s[keySecretKey].Optional = true
+	s[keySecretKey].Computed = false
+	s[keySecretKey].Description = "The secret key for the user."
+	s[keySecretKey].DiffSuppressFunc = nil
+	delete(s, keyUserSecretKeyVersion)
+	delete(s, keyUserStoreSecretKey)
+	delete(s, keyUserSecretKeyGenerated)
+	delete(s, keyUserStrict)
+	s[keyUserEnabled].Optional = false
+	s[keyUserEnabled].Default = nil
+	s[keyUserEnabled].Computed = true
 	return &schema.Resource{
 		ReadContext: resourceUserRead,
 		Schema:      s,
@@ -81,11 +209,67 @@ func datasourceUser() *schema.Resource {
 }
 
 func dataGetUserPolicies(data *schema.ResourceData) []string {
-	return dataGetStringList(data, keyUserPolicies)
+	return dataGetStringSet(data, keyUserPolicies)
 }
 
 func dataGetUserGroups(data *schema.ResourceData) []string {
-	return dataGetStringList(data, keyUserGroups)
+	return dataGetStringSet(data, keyUserGroups)
+}
+
+func dataGetUserStatus(data *schema.ResourceData) madmin.AccountStatus {
+	if data.Get(keyUserEnabled).(bool) {
+		return madmin.AccountEnabled
+	}
+	return madmin.AccountDisabled
+}
+
+// Generate a random secret key.
+func generateSecretKey() (string, error) {
+	max := big.NewInt(int64(len(secretKeyAlphabet)))
+	key := make([]byte, secretKeyLength)
+	for i := range key {
+		index, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		key[i] = secretKeyAlphabet[index.Int64()]
+	}
+	return string(key), nil
+}
+
+// If the secret key is not stored in the state, the state value is always
+// empty. The configured value is only applied when the secret key version
+// changes, so all other diffs are suppressed.
+func suppressUnstoredSecretKeyDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && !d.Get(keyUserStoreSecretKey).(bool) && !d.HasChange(keyUserSecretKeyVersion)
+}
+
+func resourceUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get(keyUserStoreSecretKey).(bool) {
+		// The secret key may only be known during apply, e.g. if it is
+		// generated by another resource.
+		if d.NewValueKnown(keySecretKey) && d.Get(keySecretKey).(string) == "" {
+			return fmt.Errorf("%s must be set if %s is false, since a generated secret key can not be returned without saving it in the state", keySecretKey, keyUserStoreSecretKey)
+		}
+		return nil
+	}
+
+	// Rotating a generated secret key produces a new, unknown value.
+	if d.Id() != "" && d.HasChange(keyUserSecretKeyVersion) && !d.HasChange(keySecretKey) && d.Get(keyUserSecretKeyGenerated).(bool) {
+		return d.SetNewComputed(keySecretKey)
+	}
+	return nil
+}
+
+// Save the secret key to the state, honoring the store_secret_key setting.
+func dataSetUserSecretKey(d *schema.ResourceData, secretKey string, generated bool) error {
+	if !d.Get(keyUserStoreSecretKey).(bool) {
+		secretKey = ""
+	}
+	if err := d.Set(keySecretKey, secretKey); err != nil {
+		return err
+	}
+	return d.Set(keyUserSecretKeyGenerated, generated)
 }
 
 func stringSliceContains(slice []string, value string) bool {
@@ -131,8 +315,8 @@ func stringSliceRemove(slice []string, value string) []string {
 
 // Check if all the specified groups exist on a Minio server.
 // Returns an error if any of the groups do not exist, or nil otherwise.
-func verifyGroupsExist(ctx context.Context, client *madmin.AdminClient, groups []string) error {
-	existingGroups, err := client.ListGroups(ctx)
+func verifyGroupsExist(ctx context.Context, mctx *minioContext, groups []string) error {
+	existingGroups, err := mctx.listGroups(ctx)
 	var missing []string
 	if err != nil {
 		return err
@@ -149,7 +333,22 @@ func verifyGroupsExist(ctx context.Context, client *madmin.AdminClient, groups [
 	return fmt.Errorf("Group(s) do not exist: %s", strings.Join(missing, ", "))
 }
 
+// Remove a partially created user again, after a step of the creation failed.
+// Returns the given diagnostics, extended with errors of the rollback.
+func userCreateRollback(ctx context.Context, client *madmin.AdminClient, accessKey string, diags diag.Diagnostics) diag.Diagnostics {
+	log.Printf("[DEBUG] Rolling back creation of minio user '%s'\n", accessKey)
+	if err := client.RemoveUser(ctx, accessKey); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Could not roll back creation of user " + accessKey + ": " + err.Error(),
+		})
+	}
+	return diags
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -158,36 +357,65 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 	secretKey := d.Get(keySecretKey).(string)
 	policies := dataGetUserPolicies(d)
 	groups := dataGetUserGroups(d)
+	strict := m.(*minioContext).strict || d.Get(keyUserStrict).(bool)
+
+	generated := false
+	if secretKey == "" {
+		var err error
+		if secretKey, err = generateSecretKey(); err != nil {
+			return diag.Errorf("Could not generate secret key: %s", err)
+		}
+		generated = true
+	}
 
 	log.Printf("[DEBUG] Creating minio user: '%s'\n", accessKey)
-	if err := client.AddUser(ctx, accessKey, secretKey); err != nil {
+	if err := client.SetUser(ctx, accessKey, secretKey, dataGetUserStatus(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
+	// The user exists from here on. In strict mode, any failure removes it
+	// again.
+	fail := func(failure diag.Diagnostics) diag.Diagnostics {
+		if !strict {
+			return failure
+		}
+		return userCreateRollback(ctx, client, accessKey, failure)
+	}
+
+	if err := dataSetUserSecretKey(d, secretKey, generated); err != nil {
+		return fail(diag.FromErr(err))
+	}
+
 	if len(policies) > 0 {
 		policyString := strings.Join(policies, ",")
 
 		if err := client.SetPolicy(ctx, policyString, accessKey, false); err != nil {
-			// TODO: the user is already created at this point, so we just add a
-			// warning if the policy could not be applied.
-			// Maybe we should hard-error instead and delete the user?
+			if strict {
+				return fail([]diag.Diagnostic{diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Could not set policy for user: " + err.Error(),
+					AttributePath: cty.GetAttrPath(keyUserPolicies),
+				}})
+			}
+			// Without strict mode, the user is kept and only a warning is
+			// added if the policy could not be applied.
 			diags = append(diags, diag.Diagnostic{
 				Severity:      diag.Warning,
 				Summary:       "Could not set policy for user: " + err.Error(),
 				AttributePath: cty.GetAttrPath(keyUserPolicies),
 			})
             if err := d.Set(keyUserPolicies, nil); err != nil {
-                return diag.FromErr(err)
+                return fail(diag.FromErr(err))
             }
 		}
 	}
 	if len(groups) > 0 {
-		if err := verifyGroupsExist(ctx, client, groups); err != nil {
-			return []diag.Diagnostic{diag.Diagnostic{
+		if err := verifyGroupsExist(ctx, m.(*minioContext), groups); err != nil {
+			return fail([]diag.Diagnostic{diag.Diagnostic{
 				Severity:      diag.Error,
 				Summary:       err.Error(),
 				AttributePath: cty.GetAttrPath(keyUserGroups),
-			}}
+			}})
 		}
 
 		var actuallyAddedGroups []string
@@ -199,7 +427,13 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 				IsRemove: false,
 			})
 
-			if err != nil {
+			if err != nil && strict {
+				return fail([]diag.Diagnostic{diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Could not add user to group " + group + ": " + err.Error(),
+					AttributePath: cty.GetAttrPath(keyUserGroups),
+				}})
+			} else if err != nil {
 				diags = append(diags, diag.Diagnostic{
 					Severity:      diag.Warning,
 					Summary:       "Could not add user to group " + group + ": " + err.Error(),
@@ -210,7 +444,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 			}
 		}
         if err := d.Set(keyUserGroups, actuallyAddedGroups); err != nil {
-            return diag.FromErr(err)
+            return fail(diag.FromErr(err))
         }
 	}
 
@@ -229,27 +463,28 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
         d.SetId(accessKey)
     }
 
-	users, err := client.ListUsers(ctx)
+	user, err := client.GetUserInfo(ctx, accessKey)
 	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchUser" {
+			return diag.Errorf("User does not exist")
+		}
 		return diag.FromErr(err)
 	}
 
-	user, found := users[accessKey]
-	if found == false {
-		return diag.Errorf("User does not exist")
-	}
-
     if err := d.Set(keyAccessKey, accessKey); err != nil {
         return diag.FromErr(err)
     }
 
-	policies := strings.Split(user.PolicyName, ",")
+	policies := splitPolicyNames(user.PolicyName)
     if err := d.Set(keyUserPolicies, policies); err != nil {
         return diag.FromErr(err)
     }
-    if err := d.Set(keyUserGroups, user.MemberOf); err != nil {
+    if err := d.Set(keyUserGroups, stringSliceFilterEmpty(user.MemberOf)); err != nil {
         return diag.FromErr(err)
     }
+	if err := d.Set(keyUserEnabled, user.Status == madmin.AccountEnabled); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// TODO: how to handle this? API seems to not return the key.
 	// d.Set(KEY_SECRET_KEY, user.SecretKey)
@@ -258,6 +493,8 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
 	if d.HasChange(keyAccessKey) {
 		return diag.FromErr(errors.New("Users can not be renamed"))
 	}
@@ -266,31 +503,69 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	client := m.(*minioContext).admin
 
 	if d.HasChange(keyUserPolicies) {
-		newPolicies := dataGetUserPolicies(d)
-		if len(newPolicies) == 0 {
-			return diag.Errorf("Can not set policies to empty after the user has been assigned other policies. This is a minio API limitation.")
+		mctx := m.(*minioContext)
+		unlock := mctx.lockPolicies()
+		defer unlock()
+
+		// Compute the changes based on the actual server state, so policies
+		// attached outside of this resource are detached as well. The
+		// attribute is the full list of policies, which is why it must not be
+		// combined with policy attachment resources.
+		oldPolicies, err := userGetPolicies(ctx, m, accessKey)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		newPolicy := strings.Join(newPolicies, ",")
-		if err := client.SetPolicy(ctx, newPolicy, accessKey, false); err != nil {
+		newPolicies := dataGetUserPolicies(d)
+		if err := mctx.updateEntityPolicies(ctx, accessKey, false, oldPolicies, newPolicies); err != nil {
 			return diag.Errorf("Could not change apply policy to user: %s", err)
 		}
 	}
 
-	if d.HasChange(keySecretKey) {
+	if d.HasChange(keySecretKey) || d.HasChange(keyUserSecretKeyVersion) {
 		newSecretKey := d.Get(keySecretKey).(string)
-		// TODO: implement enabled/disabled?
-		if err := client.SetUser(ctx, accessKey, newSecretKey, madmin.AccountEnabled); err != nil {
+		generated := d.Get(keyUserSecretKeyGenerated).(bool)
+		if d.HasChange(keySecretKey) {
+			generated = false
+		}
+		// An empty value means the secret key must be (re-)generated.
+		if newSecretKey == "" {
+			var err error
+			if newSecretKey, err = generateSecretKey(); err != nil {
+				return diag.Errorf("Could not generate secret key: %s", err)
+			}
+			generated = true
+		}
+		// Also applies the desired status, so a rotation never re-enables a
+		// disabled user.
+		if err := client.SetUser(ctx, accessKey, newSecretKey, dataGetUserStatus(d)); err != nil {
 			return diag.Errorf("Could nto change secret key: %s", err)
 		}
+		if err := dataSetUserSecretKey(d, newSecretKey, generated); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChange(keyUserEnabled) {
+		if err := client.SetUserStatus(ctx, accessKey, dataGetUserStatus(d)); err != nil {
+			return []diag.Diagnostic{diag.Diagnostic{
+				Severity:      diag.Error,
+				AttributePath: cty.GetAttrPath(keyUserEnabled),
+				Summary:       "Could not change user status: " + err.Error(),
+			}}
+		}
+	}
+
+	if d.HasChange(keyUserStoreSecretKey) && !d.Get(keyUserStoreSecretKey).(bool) {
+		if err := d.Set(keySecretKey, ""); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if d.HasChange(keyUserGroups) {
 		oldRaw, newRaw := d.GetChange(keyUserGroups)
-		old := interfaceToStringSlice(oldRaw.([]interface{}))
-		_new := interfaceToStringSlice(newRaw.([]interface{}))
+		old := interfaceToStringSlice(oldRaw.(*schema.Set).List())
+		_new := interfaceToStringSlice(newRaw.(*schema.Set).List())
 		added, removed := stringSliceDiff(old, _new)
 
-		if err := verifyGroupsExist(ctx, client, added); err != nil {
+		if err := verifyGroupsExist(ctx, m.(*minioContext), added); err != nil {
 			return []diag.Diagnostic{diag.Diagnostic{
 				Severity:      diag.Error,
 				AttributePath: cty.GetAttrPath(keyUserGroups),
@@ -341,6 +616,8 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/minio/madmin-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyUserPolicyAttachmentUser   = "user_name"
+	keyUserPolicyAttachmentPolicy = "policy_name"
+)
+
+func schemaUserPolicyAttachment() objectSchema {
+	return map[string]*schema.Schema{
+		keyUserPolicyAttachmentUser: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The access key of the user.",
+			ForceNew:    true,
+		},
+		keyUserPolicyAttachmentPolicy: &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the canned policy to attach to the user.",
+			ForceNew:    true,
+		},
+	}
+}
+
+func resourceUserPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Attaches a single canned policy to a user, leaving other policies of the user untouched.\nMust not be combined with the `policies` attribute of a `minio_user` resource for the same user, since that attribute manages the full list of policies and detaches the attached policy again. Omit the attribute instead.",
+		CreateContext: resourceUserPolicyAttachmentCreate,
+		ReadContext:   resourceUserPolicyAttachmentRead,
+		DeleteContext: resourceUserPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePolicyAttachmentImport(keyUserPolicyAttachmentUser, keyUserPolicyAttachmentPolicy),
+		},
+		Schema: schemaUserPolicyAttachment(),
+	}
+}
+
+// Build the ID for a policy attachment.
+func policyAttachmentID(entityName string, policyName string) string {
+	return entityName + "/" + policyName
+}
+
+// Returns an importer for policy attachments, which restores the entity and
+// policy names from an ID in the form of <entity>/<policy>.
+func resourcePolicyAttachmentImport(keyEntity string, keyPolicy string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		index := strings.LastIndex(d.Id(), "/")
+		if index <= 0 || index == len(d.Id())-1 {
+			return nil, fmt.Errorf("Invalid ID '%s': expected <name>/<policy>", d.Id())
+		}
+		if err := d.Set(keyEntity, d.Id()[:index]); err != nil {
+			return nil, err
+		}
+		if err := d.Set(keyPolicy, d.Id()[index+1:]); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// Load the policies currently assigned to a user.
+func userGetPolicies(ctx context.Context, m interface{}, accessKey string) ([]string, error) {
+	client := m.(*minioContext).admin
+	info, err := client.GetUserInfo(ctx, accessKey)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load user %s: %s", accessKey, err)
+	}
+	return splitPolicyNames(info.PolicyName), nil
+}
+
+func resourceUserPolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	mctx := m.(*minioContext)
+	accessKey := d.Get(keyUserPolicyAttachmentUser).(string)
+	policyName := d.Get(keyUserPolicyAttachmentPolicy).(string)
+
+	// Multiple attachments for the same user may be applied concurrently, so
+	// the read-modify-write cycle must be serialized.
+	unlock := mctx.lockPolicies()
+	defer unlock()
+
+	policies, err := userGetPolicies(ctx, m, accessKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !stringSliceContains(policies, policyName) {
+		log.Printf("[DEBUG] Attaching policy '%s' to minio user '%s'\n", policyName, accessKey)
+		if err := mctx.updateEntityPolicies(ctx, accessKey, false, policies, append(policies, policyName)); err != nil {
+			return diag.Errorf("Could not attach policy %s to user %s: %s", policyName, accessKey, err)
+		}
+	}
+
+	d.SetId(policyAttachmentID(accessKey, policyName))
+	return diags
+}
+
+func resourceUserPolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	client := m.(*minioContext).admin
+	accessKey := d.Get(keyUserPolicyAttachmentUser).(string)
+	policyName := d.Get(keyUserPolicyAttachmentPolicy).(string)
+
+	info, err := client.GetUserInfo(ctx, accessKey)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchUser" {
+			log.Printf("[WARN] Minio user '%s' no longer exists\n", accessKey)
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("Could not load user %s: %s", accessKey, err)
+	}
+
+	if !stringSliceContains(splitPolicyNames(info.PolicyName), policyName) {
+		log.Printf("[WARN] Policy '%s' is no longer attached to minio user '%s'\n", policyName, accessKey)
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func resourceUserPolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	defer m.(*minioContext).invalidateListCache()
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	mctx := m.(*minioContext)
+	accessKey := d.Get(keyUserPolicyAttachmentUser).(string)
+	policyName := d.Get(keyUserPolicyAttachmentPolicy).(string)
+
+	unlock := mctx.lockPolicies()
+	defer unlock()
+
+	policies, err := userGetPolicies(ctx, m, accessKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if stringSliceContains(policies, policyName) {
+		log.Printf("[DEBUG] Detaching policy '%s' from minio user '%s'\n", policyName, accessKey)
+		if err := mctx.updateEntityPolicies(ctx, accessKey, false, policies, stringSliceRemove(policies, policyName)); err != nil {
+			return diag.Errorf("Could not detach policy %s from user %s: %s", policyName, accessKey, err)
+		}
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
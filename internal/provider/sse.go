@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Shared functionality for server side encryption of objects.
+
+const (
+	keySSEType        = "type"
+	keySSEKMSKeyID    = "kms_key_id"
+	keySSEKMSContext  = "kms_context"
+	keySSECustomerKey = "customer_key"
+)
+
+const (
+	sseTypeS3  = "SSE-S3"
+	sseTypeKMS = "SSE-KMS"
+	sseTypeC   = "SSE-C"
+)
+
+// Build the schema of a server side encryption block.
+// If forceNew is true, any change to the block recreates the resource.
+func schemaSSE(description string, forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    forceNew,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: objectSchema{
+				keySSEType: &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The type of encryption: `SSE-S3` uses a key managed by the server, `SSE-KMS` uses a key of the KMS and `SSE-C` uses `customer_key`.",
+					ForceNew:     forceNew,
+					ValidateFunc: validation.StringInSlice([]string{sseTypeS3, sseTypeKMS, sseTypeC}, false),
+				},
+				keySSEKMSKeyID: &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The ID of the KMS key. Required for `SSE-KMS`.",
+					ForceNew:    forceNew,
+				},
+				keySSEKMSContext: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional:    true,
+					Description: "The KMS encryption context. Only for `SSE-KMS`.",
+					ForceNew:    forceNew,
+				},
+				keySSECustomerKey: &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					Description:  "The base64 encoded 256 bit customer key. Required for `SSE-C`.\nThe same key must be provided to read the objects.",
+					ForceNew:     forceNew,
+					ValidateFunc: validateSSECustomerKey,
+				},
+			},
+		},
+	}
+}
+
+func validateSSECustomerKey(v interface{}, k string) (ws []string, es []error) {
+	key, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be base64 encoded: %s", k, err)}
+	}
+	if len(key) != 32 {
+		return nil, []error{fmt.Errorf("%s must be 32 bytes long, got %d", k, len(key))}
+	}
+	return nil, nil
+}
+
+// Build the server side encryption from a block.
+// Returns nil if the block is not set.
+func dataGetSSE(data *schema.ResourceData, key string) (encrypt.ServerSide, error) {
+	blocks := data.Get(key).([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, nil
+	}
+	block := blocks[0].(map[string]interface{})
+	sseType := block[keySSEType].(string)
+	kmsKeyID := block[keySSEKMSKeyID].(string)
+	kmsContext := block[keySSEKMSContext].(map[string]interface{})
+	customerKey := block[keySSECustomerKey].(string)
+
+	if sseType != sseTypeKMS && (kmsKeyID != "" || len(kmsContext) > 0) {
+		return nil, fmt.Errorf("%s and %s are only supported for %s", keySSEKMSKeyID, keySSEKMSContext, sseTypeKMS)
+	}
+	if sseType != sseTypeC && customerKey != "" {
+		return nil, fmt.Errorf("%s is only supported for %s", keySSECustomerKey, sseTypeC)
+	}
+
+	switch sseType {
+	case sseTypeS3:
+		return encrypt.NewSSE(), nil
+	case sseTypeKMS:
+		if kmsKeyID == "" {
+			return nil, fmt.Errorf("%s is required for %s", keySSEKMSKeyID, sseTypeKMS)
+		}
+		var context interface{}
+		if len(kmsContext) > 0 {
+			context = kmsContext
+		}
+		return encrypt.NewSSEKMS(kmsKeyID, context)
+	case sseTypeC:
+		if customerKey == "" {
+			return nil, fmt.Errorf("%s is required for %s", keySSECustomerKey, sseTypeC)
+		}
+		return parseSSECustomerKey(customerKey)
+	default:
+		return nil, fmt.Errorf("Unsupported encryption type %s", sseType)
+	}
+}
+
+// Build SSE-C encryption from a base64 encoded key.
+func parseSSECustomerKey(customerKey string) (encrypt.ServerSide, error) {
+	key, err := base64.StdEncoding.DecodeString(customerKey)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt.NewSSEC(key)
+}
+
+// Determine the type of server side encryption from the response headers
+// of an object.
+// Returns an empty string for unencrypted objects.
+func sseTypeFromHeaders(headers http.Header) string {
+	if headers.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm") != "" {
+		return sseTypeC
+	}
+	switch headers.Get("X-Amz-Server-Side-Encryption") {
+	case "AES256":
+		return sseTypeS3
+	case "aws:kms":
+		return sseTypeKMS
+	}
+	return ""
+}
+
+// Update the type in a server side encryption block to the observed type, so
+// objects that were re-encrypted outside of terraform show up as changes.
+// Blocks that are not set are not changed, since buckets can encrypt
+// objects by default.
+func dataSetSSEType(data *schema.ResourceData, key string, observed string) error {
+	blocks := data.Get(key).([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	if block[keySSEType].(string) == observed {
+		return nil
+	}
+	block[keySSEType] = observed
+	return data.Set(key, []interface{}{block})
+}